@@ -0,0 +1,197 @@
+package apiclienttest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Forever can be given as the Times of a Fault so that it affects every request.
+const Forever = -1
+
+// Fault is a way for the server to misbehave when answering a request. A Fault with only a
+// Delay answers normally once the delay has passed.
+type Fault struct {
+	// Status answers with this status code and an error body.
+	Status int
+	// RetryAfter sets the Retry-After header, in whole seconds, on a Status response such as a 429.
+	RetryAfter time.Duration
+	// Delay waits before answering, or until the request is cancelled.
+	Delay time.Duration
+	// DropConnection closes the connection part way through the response body.
+	DropConnection bool
+	// MalformedJSON answers with a 200, or Status if it is set, and a body which is not valid JSON.
+	MalformedJSON bool
+	// Times is the number of requests the fault affects, or Forever. Zero means once.
+	Times int
+}
+
+// Script is a sequence of faults injected into the requests for a route. Each fault affects
+// its number of requests in turn, after which requests are answered normally.
+type Script struct {
+	server *Server
+	method string
+	path   string
+	faults []Fault
+
+	// requests is the number of requests matching the route, and served the number answered with a fault.
+	requests int
+	served   int
+}
+
+// Inject adds faults to the requests with method, or any method if it is empty, to path.
+// A path ending in "*" matches every path starting with the rest of it.
+// Requests which match several scripts take their faults from the first added.
+func (s *Server) Inject(method string, path string, faults ...Fault) *Script {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script := &Script{server: s, method: method, path: path, faults: faults}
+	s.scripts = append(s.scripts, script)
+	return script
+}
+
+// ClearFaults removes every Script.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scripts = nil
+}
+
+// Requests returns the number of requests which have matched the script's route.
+func (sc *Script) Requests() int {
+	sc.server.mu.Lock()
+	defer sc.server.mu.Unlock()
+
+	return sc.requests
+}
+
+// Served returns the number of requests which have been answered with one of the script's faults.
+func (sc *Script) Served() int {
+	sc.server.mu.Lock()
+	defer sc.server.mu.Unlock()
+
+	return sc.served
+}
+
+// Done reports whether every fault of the script has affected its number of requests.
+// A script with a Forever fault is never done.
+func (sc *Script) Done() bool {
+	sc.server.mu.Lock()
+	defer sc.server.mu.Unlock()
+
+	_, remaining := sc.next()
+	return !remaining
+}
+
+// matches reports whether req is for the script's route.
+func (sc *Script) matches(req *http.Request) bool {
+	if sc.method != "" && sc.method != req.Method {
+		return false
+	}
+	if strings.HasSuffix(sc.path, "*") {
+		return strings.HasPrefix(req.URL.Path, strings.TrimSuffix(sc.path, "*"))
+	}
+	return req.URL.Path == sc.path
+}
+
+// next returns the fault for the script's next request, and whether there is one.
+func (sc *Script) next() (Fault, bool) {
+	served := sc.served
+	for _, fault := range sc.faults {
+		times := fault.Times
+		switch {
+		case times == Forever:
+			return fault, true
+		case times == 0:
+			times = 1
+		}
+		if served < times {
+			return fault, true
+		}
+		served -= times
+	}
+	return Fault{}, false
+}
+
+// fault returns the fault to apply to req, if any, and counts the request against the scripts it matches.
+func (s *Server) fault(req *http.Request) (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var fault Fault
+	found := false
+	for _, script := range s.scripts {
+		if !script.matches(req) {
+			continue
+		}
+		script.requests++
+		if found {
+			continue
+		}
+		if fault, found = script.next(); found {
+			script.served++
+		}
+	}
+	return fault, found
+}
+
+// apply answers req with the fault. It reports whether the request should still be answered normally.
+func (f Fault) apply(rw http.ResponseWriter, req *http.Request) bool {
+	if f.Delay > 0 {
+		select {
+		case <-time.After(f.Delay):
+		case <-req.Context().Done():
+			return false
+		}
+	}
+
+	switch {
+	case f.DropConnection:
+		dropConnection(rw)
+		return false
+
+	case f.MalformedJSON:
+		status := f.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		rw.Header().Set("Content-Type", "application/vnd.api+json")
+		rw.WriteHeader(status)
+		rw.Write([]byte(`{"data":{"type":"accounts","id":`))
+		return false
+
+	case f.Status != 0:
+		if f.RetryAfter > 0 {
+			rw.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Round(time.Second)/time.Second)))
+		}
+		writeError(rw, f.Status, fmt.Sprintf("injected fault: %s", http.StatusText(f.Status)))
+		return false
+	}
+
+	return true
+}
+
+// dropConnection starts a response promising a longer body than is sent, then closes the connection.
+// If the connection cannot be taken over, such as with HTTP/2, it responds with an internal server error instead.
+func dropConnection(rw http.ResponseWriter) {
+	hijacker, ok := rw.(http.Hijacker)
+	if !ok {
+		writeError(rw, http.StatusInternalServerError, "injected fault: the connection cannot be dropped")
+		return
+	}
+
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, fmt.Sprintf("injected fault: the connection cannot be dropped: %v", err))
+		return
+	}
+	defer conn.Close()
+
+	body := `{"data":{"type":"accounts",`
+	fmt.Fprintf(buf, "HTTP/1.1 200 OK\r\nContent-Type: application/vnd.api+json\r\nContent-Length: %d\r\n\r\n%s", len(body)*4, body)
+	buf.Flush()
+}
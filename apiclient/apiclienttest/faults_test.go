@@ -0,0 +1,131 @@
+package apiclienttest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rosalita/my-apiclient/apiclient"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRetryingClient returns a client for the server which keeps retrying for long enough to get past injected faults.
+func newRetryingClient(server *Server) *apiclient.Client {
+	return apiclient.New(server.URL, 2*time.Second, 10*time.Second)
+}
+
+func TestFaultStatusThenSuccess(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := newRetryingClient(server)
+
+	account := newAccount(t, organisationID)
+	server.Add(account.Data)
+
+	script := server.Inject("GET", "/v1/organisation/accounts/*", Fault{Status: 503, Times: 2})
+
+	fetched, err := apiclient.Fetch(client, account.Data.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, account.Data.ID, fetched.Data.ID)
+
+	assert.Equal(t, 3, script.Requests())
+	assert.Equal(t, 2, script.Served())
+	assert.True(t, script.Done())
+	assert.Equal(t, int64(2), client.Stats().Retries)
+}
+
+func TestFaultRetryAfter(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	script := server.Inject("", "/v1/organisation/accounts", Fault{Status: 429, RetryAfter: 2 * time.Second, Times: Forever})
+
+	for i := 0; i < 2; i++ {
+		resp, err := http.Get(server.URL + "/v1/organisation/accounts")
+		assert.Equal(t, nil, err)
+		resp.Body.Close()
+		assert.Equal(t, 429, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	}
+
+	assert.Equal(t, 2, script.Served())
+	assert.False(t, script.Done())
+
+	server.ClearFaults()
+	resp, err := http.Get(server.URL + "/v1/organisation/accounts")
+	assert.Equal(t, nil, err)
+	resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestFaultDelay(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := newRetryingClient(server)
+	client.AttemptTimeout = 50 * time.Millisecond
+
+	script := server.Inject("GET", "/v1/organisation/accounts", Fault{Delay: time.Second}, Fault{Delay: 10 * time.Millisecond})
+
+	start := time.Now()
+	_, err := apiclient.List(client, nil)
+	assert.Equal(t, nil, err)
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, 2, script.Served())
+	assert.Equal(t, int64(1), client.Stats().Retries)
+}
+
+func TestFaultDropConnection(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := newRetryingClient(server)
+
+	account := newAccount(t, organisationID)
+	server.Add(account.Data)
+
+	script := server.Inject("GET", "/v1/organisation/accounts/"+account.Data.ID, Fault{DropConnection: true})
+
+	_, err := apiclient.Fetch(client, account.Data.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, script.Served())
+	assert.Equal(t, int64(1), client.Stats().Retries)
+}
+
+func TestFaultDropConnectionWithoutHijacker(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Inject("GET", "/v1/organisation/accounts", Fault{DropConnection: true})
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/organisation/accounts", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "the connection cannot be dropped")
+}
+
+func TestFaultMalformedJSON(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := newRetryingClient(server)
+
+	server.Inject("GET", "/v1/organisation/accounts", Fault{MalformedJSON: true})
+
+	_, err := apiclient.List(client, nil)
+	assert.IsType(t, &json.SyntaxError{}, err)
+
+	_, err = apiclient.List(client, nil)
+	assert.Equal(t, nil, err)
+}
+
+func TestFaultsOnlyAffectTheirRoute(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := newRetryingClient(server)
+
+	create := server.Inject("POST", "/v1/organisation/accounts", Fault{Status: 500, Times: Forever})
+
+	_, err := apiclient.List(client, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, create.Requests())
+}
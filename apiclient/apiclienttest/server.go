@@ -0,0 +1,515 @@
+// Package apiclienttest provides an in-memory fake of the Accounts API for testing code which uses apiclient.
+package apiclienttest
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rosalita/my-apiclient/apiclient"
+)
+
+const (
+	accountsPath = "/v1/organisation/accounts"
+	// maxPageSize is the largest page of accounts the Accounts API returns.
+	maxPageSize = 100
+)
+
+// listFilters map the filter query parameters of the list endpoint to the account values they match.
+var listFilters = map[string]func(account *apiclient.Account) string{
+	"organisation_id": func(account *apiclient.Account) string { return account.OrganisationID },
+	"country":         func(account *apiclient.Account) string { return string(account.Attributes.Country) },
+	"bank_id":         func(account *apiclient.Account) string { return account.Attributes.BankID },
+	"bank_id_code":    func(account *apiclient.Account) string { return string(account.Attributes.BankIDCode) },
+	"account_number":  func(account *apiclient.Account) string { return account.Attributes.AccountNumber },
+	"iban":            func(account *apiclient.Account) string { return account.Attributes.Iban },
+}
+
+// Server is a fake Accounts API holding accounts in memory. It creates, fetches, lists, updates
+// and deletes accounts as the Accounts API does: accounts are validated, versions are checked,
+// lists are paginated and filtered, and missing accounts and version conflicts give 404 and 409 responses.
+type Server struct {
+	// URL is the base URL of the server, for apiclient.New.
+	URL string
+	// Validator checks the accounts created and updated.
+	Validator apiclient.Validator
+	// DisableValidation accepts accounts which break the rules checked by the Validator.
+	DisableValidation bool
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	accounts map[string]apiclient.Account
+	// order holds the ids of the accounts in the order they were created, which is the order they are listed in.
+	order   []string
+	scripts []*Script
+}
+
+// NewServer starts a Server. It should be closed when the test finishes.
+func NewServer() *Server {
+	s := &Server{accounts: make(map[string]apiclient.Account)}
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns an apiclient.Client for the server, with short timeouts suited to tests.
+func (s *Server) Client() *apiclient.Client {
+	return apiclient.New(s.URL, 10*time.Millisecond, 10*time.Second)
+}
+
+// Add stores accounts as if they had been created, without validating them.
+func (s *Server) Add(accounts ...apiclient.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, account := range accounts {
+		if account.Version == nil {
+			account.Version = intPtr(0)
+		}
+		s.store(account)
+	}
+}
+
+// Account returns the stored account with id.
+func (s *Server) Account(id string) (apiclient.Account, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[id]
+	return account, ok
+}
+
+// Accounts returns every stored account in the order they were created.
+func (s *Server) Accounts() []apiclient.Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accounts := make([]apiclient.Account, 0, len(s.order))
+	for _, id := range s.order {
+		accounts = append(accounts, s.accounts[id])
+	}
+	return accounts
+}
+
+// Reset deletes every stored account.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accounts = make(map[string]apiclient.Account)
+	s.order = nil
+}
+
+// ServeHTTP serves the accounts endpoints of the Accounts API, applying any faults injected for the request.
+func (s *Server) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if fault, ok := s.fault(req); ok && !fault.apply(rw, req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	switch {
+	case path == accountsPath && req.Method == "POST":
+		s.create(rw, req)
+	case path == accountsPath && req.Method == "GET":
+		s.list(rw, req)
+	case strings.HasPrefix(path, accountsPath+"/"):
+		id := strings.TrimPrefix(path, accountsPath+"/")
+		switch req.Method {
+		case "GET":
+			s.fetch(rw, id)
+		case "PATCH":
+			s.update(rw, req, id)
+		case "DELETE":
+			s.delete(rw, req, id)
+		default:
+			writeError(rw, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	default:
+		writeError(rw, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) create(rw http.ResponseWriter, req *http.Request) {
+	var accountData apiclient.AccountData
+	if err := readJSON(req, &accountData); err != nil {
+		writeError(rw, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !s.DisableValidation {
+		if err := s.Validator.Validate(&accountData); err != nil {
+			writeError(rw, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	account := accountData.Data
+	if _, exists := s.accounts[account.ID]; exists {
+		writeError(rw, http.StatusConflict, "Account cannot be created as it violates a duplicate constraint")
+		return
+	}
+
+	now := timestamp()
+	account.Version = intPtr(0)
+	account.Extra = map[string]json.RawMessage{"created_on": now, "modified_on": now}
+	s.store(account)
+
+	writeAccount(rw, http.StatusCreated, account)
+}
+
+func (s *Server) fetch(rw http.ResponseWriter, id string) {
+	account, ok := s.accounts[id]
+	if !ok {
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+
+	writeAccount(rw, http.StatusOK, account)
+}
+
+func (s *Server) list(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	size, err := pageParam(query.Get("page[size]"), maxPageSize, 0)
+	if err != nil || size < 1 || size > maxPageSize {
+		writeError(rw, http.StatusBadRequest, fmt.Sprintf("page[size] must be between 1 and %d", maxPageSize))
+		return
+	}
+
+	var accounts []apiclient.Account
+	for _, id := range s.order {
+		account := s.accounts[id]
+		if matchesFilters(&account, query) {
+			accounts = append(accounts, account)
+		}
+	}
+
+	lastPage := 0
+	if len(accounts) > 0 {
+		lastPage = (len(accounts) - 1) / size
+	}
+
+	number, err := pageParam(query.Get("page[number]"), 0, lastPage)
+	if err != nil || number < 0 {
+		writeError(rw, http.StatusBadRequest, "page[number] must be a number, first or last")
+		return
+	}
+
+	start := number * size
+	end := start + size
+	if start > len(accounts) {
+		start = len(accounts)
+	}
+	if end > len(accounts) {
+		end = len(accounts)
+	}
+
+	links := apiclient.PageLinks{
+		First: pageLink(query, 0, size),
+		Last:  pageLink(query, lastPage, size),
+		Self:  pageLink(query, number, size),
+	}
+	if number < lastPage {
+		links.Next = pageLink(query, number+1, size)
+	}
+	if number > 0 {
+		links.Prev = pageLink(query, number-1, size)
+	}
+
+	writeJSON(rw, http.StatusOK, apiclient.AccountListData{Data: append([]apiclient.Account{}, accounts[start:end]...), Links: links})
+}
+
+func (s *Server) update(rw http.ResponseWriter, req *http.Request, id string) {
+	stored, ok := s.accounts[id]
+	if !ok {
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+
+	var patch struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := readJSON(req, &patch); err != nil {
+		writeError(rw, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var versioned struct {
+		ID      string `json:"id"`
+		Version *int   `json:"version"`
+	}
+	if err := json.Unmarshal(patch.Data, &versioned); err != nil {
+		writeError(rw, http.StatusBadRequest, err.Error())
+		return
+	}
+	if versioned.Version == nil {
+		writeError(rw, http.StatusBadRequest, "version is required")
+		return
+	}
+	if *versioned.Version != *stored.Version {
+		writeError(rw, http.StatusConflict, "invalid version")
+		return
+	}
+	if versioned.ID != "" && versioned.ID != id {
+		writeError(rw, http.StatusBadRequest, "id cannot be changed")
+		return
+	}
+
+	current, err := json.Marshal(stored)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// apiclient always sends the type and organisation_id of an account, so empty values mean
+	// they were not set rather than that they should be cleared.
+	patchData, err := withoutEmpty(patch.Data, "type", "organisation_id")
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err.Error())
+		return
+	}
+	merged, err := mergePatch(current, patchData)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var account apiclient.Account
+	if err := json.Unmarshal(merged, &account); err != nil {
+		writeError(rw, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !s.DisableValidation {
+		if err := s.Validator.Validate(&apiclient.AccountData{Data: account}); err != nil {
+			writeError(rw, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	account.ID = id
+	account.Version = intPtr(*stored.Version + 1)
+	if account.Extra == nil {
+		account.Extra = map[string]json.RawMessage{}
+	}
+	account.Extra["modified_on"] = timestamp()
+	s.store(account)
+
+	writeAccount(rw, http.StatusOK, account)
+}
+
+func (s *Server) delete(rw http.ResponseWriter, req *http.Request, id string) {
+	version, err := strconv.Atoi(req.URL.Query().Get("version"))
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "version is required")
+		return
+	}
+
+	account, ok := s.accounts[id]
+	if !ok {
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+	if version != *account.Version {
+		writeError(rw, http.StatusConflict, "invalid version")
+		return
+	}
+
+	delete(s.accounts, id)
+	for i, orderedID := range s.order {
+		if orderedID == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// store saves account, keeping its place in the order if it already exists. s.mu must be held.
+func (s *Server) store(account apiclient.Account) {
+	if _, exists := s.accounts[account.ID]; !exists {
+		s.order = append(s.order, account.ID)
+	}
+	s.accounts[account.ID] = account
+}
+
+// matchesFilters reports whether account matches every filter[...] parameter in query.
+// A filter can list several values separated by commas.
+func matchesFilters(account *apiclient.Account, query url.Values) bool {
+	for name, field := range listFilters {
+		values := query.Get("filter[" + name + "]")
+		if values == "" {
+			continue
+		}
+
+		matched := false
+		for _, value := range strings.Split(values, ",") {
+			if field(account) == value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// pageParam parses a page query parameter, which is a number or "first" or "last". An empty value is fallback.
+func pageParam(value string, fallback int, last int) (int, error) {
+	switch value {
+	case "":
+		return fallback, nil
+	case "first":
+		return 0, nil
+	case "last":
+		return last, nil
+	default:
+		return strconv.Atoi(value)
+	}
+}
+
+// pageLink returns the link to a page of the list with the same filters as query.
+func pageLink(query url.Values, number int, size int) string {
+	linkQuery := url.Values{}
+	for name, values := range query {
+		if strings.HasPrefix(name, "filter[") {
+			linkQuery[name] = values
+		}
+	}
+	linkQuery.Set("page[number]", strconv.Itoa(number))
+	linkQuery.Set("page[size]", strconv.Itoa(size))
+	return accountsPath + "?" + linkQuery.Encode()
+}
+
+// mergePatch applies a JSON merge patch (RFC 7386) to target: members of patch replace those of
+// target, objects are merged recursively and null members are removed.
+func mergePatch(target []byte, patch []byte) ([]byte, error) {
+	var patchMembers map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &patchMembers); err != nil || patchMembers == nil {
+		return patch, nil
+	}
+
+	var targetMembers map[string]json.RawMessage
+	if err := json.Unmarshal(target, &targetMembers); err != nil || targetMembers == nil {
+		targetMembers = map[string]json.RawMessage{}
+	}
+
+	names := make([]string, 0, len(patchMembers))
+	for name := range patchMembers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := patchMembers[name]
+		if string(value) == "null" {
+			delete(targetMembers, name)
+			continue
+		}
+		merged, err := mergePatch(targetMembers[name], value)
+		if err != nil {
+			return nil, err
+		}
+		targetMembers[name] = merged
+	}
+
+	return json.Marshal(targetMembers)
+}
+
+// withoutEmpty removes the named members of the JSON object in data whose values are empty strings.
+func withoutEmpty(data []byte, names ...string) ([]byte, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if string(members[name]) == `""` {
+			delete(members, name)
+		}
+	}
+	return json.Marshal(members)
+}
+
+// readJSON unmarshals the body of req into v, decompressing it if it was sent gzip compressed.
+func readJSON(req *http.Request, v interface{}) error {
+	var reader io.Reader = req.Body
+	switch coding := req.Header.Get("Content-Encoding"); coding {
+	case "", "identity":
+	case "gzip":
+		zr, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		reader = zr
+	default:
+		return fmt.Errorf("unsupported content encoding %q", coding)
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func writeAccount(rw http.ResponseWriter, status int, account apiclient.Account) {
+	writeJSON(rw, status, apiclient.AccountData{
+		Data:  account,
+		Links: apiclient.PageLinks{Self: accountsPath + "/" + account.ID},
+	})
+}
+
+func writeError(rw http.ResponseWriter, status int, message string) {
+	writeJSON(rw, status, map[string]string{"error_message": message})
+}
+
+func writeJSON(rw http.ResponseWriter, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body, _ = json.Marshal(map[string]string{"error_message": err.Error()})
+	}
+
+	rw.Header().Set("Content-Type", "application/vnd.api+json")
+	rw.WriteHeader(status)
+	rw.Write(body)
+}
+
+func timestamp() json.RawMessage {
+	data, _ := json.Marshal(time.Now().UTC().Format("2006-01-02T15:04:05.000Z"))
+	return data
+}
+
+// IDs returns the ids of the accounts in a page, in order.
+func IDs(accountList *apiclient.AccountListData) []string {
+	ids := []string{}
+	for _, account := range accountList.Data {
+		ids = append(ids, account.ID)
+	}
+	return ids
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,249 @@
+package apiclienttest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rosalita/my-apiclient/apiclient"
+	"github.com/stretchr/testify/assert"
+)
+
+const organisationID = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+
+var errStatus = errors.New("status code not ok")
+
+func newAccount(t *testing.T, organisationID string) *apiclient.AccountData {
+	account, err := apiclient.NewGBAccount(organisationID).
+		WithSortCode("400300").
+		WithAccountNumber("41426819").
+		WithBIC("NWBKGB22").
+		WithName("Samantha Holder").
+		Personal().
+		Build()
+	assert.Equal(t, nil, err)
+	return account
+}
+
+func TestAccountLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	account := newAccount(t, organisationID)
+
+	created, err := apiclient.Create(client, account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, *created.Data.Version)
+	assert.Contains(t, created.Data.Extra, "created_on")
+
+	fetched, err := apiclient.Fetch(client, account.Data.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, created.Data, fetched.Data)
+
+	accountList, err := apiclient.List(client, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []apiclient.Account{created.Data}, accountList.Data)
+
+	fetched.Data.Attributes.Name = apiclient.Some([]string{"Samantha Smith"})
+	fetched.Data.Attributes.JointAccount = apiclient.Some(true)
+	updated, err := apiclient.Update(client, fetched)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, *updated.Data.Version)
+	assert.Equal(t, apiclient.Some([]string{"Samantha Smith"}), updated.Data.Attributes.Name)
+	assert.Equal(t, apiclient.Some(true), updated.Data.Attributes.JointAccount)
+
+	err = apiclient.Delete(client, account.Data.ID, 1)
+	assert.Equal(t, nil, err)
+
+	_, err = apiclient.Fetch(client, account.Data.ID)
+	assert.Equal(t, errStatus, err)
+	assert.Equal(t, 0, len(server.Accounts()))
+}
+
+func TestCreateConflictsAndValidation(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	account := newAccount(t, organisationID)
+	_, err := apiclient.Create(client, account)
+	assert.Equal(t, nil, err)
+
+	_, err = apiclient.Create(client, account)
+	assert.Equal(t, errStatus, err)
+
+	// The server validates accounts even when the client does not.
+	client.DisableValidation = true
+	invalid := newAccount(t, organisationID)
+	invalid.Data.Attributes.BankID = "4003"
+	_, err = apiclient.Create(client, invalid)
+	assert.Equal(t, errStatus, err)
+
+	resp, err := http.Post(server.URL+"/v1/organisation/accounts", "application/vnd.api+json", strings.NewReader(`{"data":{"type":"accounts"}}`))
+	assert.Equal(t, nil, err)
+	resp.Body.Close()
+	assert.Equal(t, 400, resp.StatusCode)
+
+	assert.Equal(t, 1, len(server.Accounts()))
+}
+
+func TestVersionConflicts(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	account := newAccount(t, organisationID)
+	server.Add(account.Data)
+
+	stale := *account
+	stale.Data.Version = new(int)
+	*stale.Data.Version = 3
+	_, err := apiclient.Update(client, &stale)
+	assert.Equal(t, errStatus, err)
+
+	err = apiclient.Delete(client, account.Data.ID, 3)
+	assert.Equal(t, errStatus, err)
+
+	err = apiclient.Delete(client, "missing", 0)
+	assert.Equal(t, errStatus, err)
+
+	statuses := map[string]int{}
+	for name, url := range map[string]string{
+		"conflict":        server.URL + "/v1/organisation/accounts/" + account.Data.ID + "?version=3",
+		"missing":         server.URL + "/v1/organisation/accounts/missing?version=0",
+		"missing version": server.URL + "/v1/organisation/accounts/" + account.Data.ID,
+	} {
+		req, _ := http.NewRequest("DELETE", url, nil)
+		resp, err := http.DefaultClient.Do(req)
+		assert.Equal(t, nil, err)
+		resp.Body.Close()
+		statuses[name] = resp.StatusCode
+	}
+	assert.Equal(t, map[string]int{"conflict": 409, "missing": 404, "missing version": 400}, statuses)
+
+	_, ok := server.Account(account.Data.ID)
+	assert.True(t, ok)
+}
+
+func TestListPaginationAndFilters(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	for i := 0; i < 5; i++ {
+		org := organisationID
+		if i%2 == 1 {
+			org = "0d27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+		}
+		account := newAccount(t, org)
+		account.Data.ID = fmt.Sprintf("%d", i)
+		server.Add(account.Data)
+	}
+
+	zero, one, two := 0, 1, 2
+	first, err := apiclient.List(client, &apiclient.ListParams{PageNum: &zero, PageSize: &two})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"0", "1"}, IDs(first))
+	assert.Equal(t, "/v1/organisation/accounts?page%5Bnumber%5D=0&page%5Bsize%5D=2", first.Links.First)
+	assert.Equal(t, "/v1/organisation/accounts?page%5Bnumber%5D=2&page%5Bsize%5D=2", first.Links.Last)
+	assert.Equal(t, "/v1/organisation/accounts?page%5Bnumber%5D=1&page%5Bsize%5D=2", first.Links.Next)
+	assert.Equal(t, "", first.Links.Prev)
+
+	second, err := apiclient.List(client, &apiclient.ListParams{PageNum: &one, PageSize: &two})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"2", "3"}, IDs(second))
+	assert.Equal(t, first.Links.First, second.Links.Prev)
+
+	last, err := apiclient.List(client, &apiclient.ListParams{PageNum: &two, PageSize: &two})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"4"}, IDs(last))
+	assert.Equal(t, "", last.Links.Next)
+
+	filtered, err := client.Organisation(organisationID).List(nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"0", "2", "4"}, IDs(filtered))
+
+	_, err = apiclient.List(client, &apiclient.ListParams{PageSize: &zero})
+	assert.Equal(t, errStatus, err)
+}
+
+func TestUpdateMergesPatch(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	account := newAccount(t, organisationID)
+	account.Data.Attributes.JointAccount = apiclient.Some(true)
+	account.Data.Attributes.Title = apiclient.Some("Ms")
+	server.Add(account.Data)
+
+	// Only the attributes sent are changed, and null attributes are removed.
+	patch := &apiclient.AccountData{Data: apiclient.Account{
+		ID:      account.Data.ID,
+		Version: new(int),
+		Attributes: apiclient.AccountAttributes{
+			SecondaryIdentification: apiclient.Some("A1B2C3D4"),
+			JointAccount:            apiclient.Null[bool](),
+			Title:                   apiclient.Null[string](),
+		},
+	}}
+	updated, err := apiclient.Update(client, patch)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, apiclient.Some("A1B2C3D4"), updated.Data.Attributes.SecondaryIdentification)
+	assert.Equal(t, account.Data.Attributes.Iban, updated.Data.Attributes.Iban)
+	assert.Equal(t, organisationID, updated.Data.OrganisationID)
+	assert.True(t, updated.Data.Attributes.JointAccount.IsZero())
+	assert.True(t, updated.Data.Attributes.Title.IsZero())
+}
+
+func TestCompressedRequests(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	client.Compression = apiclient.NewCompression()
+	client.Compression.RequestThreshold = 1
+
+	account := newAccount(t, organisationID)
+	created, err := apiclient.Create(client, account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, account.Data.Attributes.Name, created.Data.Attributes.Name)
+
+	created.Data.Attributes.Title = apiclient.Some("Ms")
+	updated, err := apiclient.Update(client, created)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, apiclient.Some("Ms"), updated.Data.Attributes.Title)
+
+	var body bytes.Buffer
+	zw := gzip.NewWriter(&body)
+	zw.Write([]byte(`{"data":`))
+	zw.Close()
+	req := httptest.NewRequest(http.MethodPost, accountsPath, &body)
+	req.Header.Set("Content-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, accountsPath, strings.NewReader(`{}`))
+	req.Header.Set("Content-Encoding", "br")
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `{"error_message":"unsupported content encoding \"br\""}`, rec.Body.String())
+}
+
+func TestWriteJSONError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeJSON(rec, http.StatusOK, make(chan int))
+
+	var body map[string]string
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "json: unsupported type: chan int", body["error_message"])
+}
@@ -0,0 +1,167 @@
+package apiclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when a request is rejected because the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+// The states of a CircuitBreaker.
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker stops requests being made to the Accounts API while it is failing.
+// Requests pass through while the circuit is closed. After FailureThreshold consecutive
+// failed attempts the circuit opens and requests fail straight away with ErrCircuitOpen.
+// Once CoolDown has passed the circuit is half-open and lets one trial request through at a time;
+// SuccessThreshold successful trials close the circuit again and a failed trial re-opens it.
+type CircuitBreaker struct {
+	FailureThreshold int
+	SuccessThreshold int
+	CoolDown         time.Duration
+	OnStateChange    func(from, to CircuitState)
+
+	mu            sync.Mutex
+	state         CircuitState
+	failures      int
+	successes     int
+	openedAt      time.Time
+	trialInFlight bool
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker which opens after failureThreshold
+// consecutive failures and stays open for coolDown.
+func NewCircuitBreaker(failureThreshold int, coolDown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureThreshold: failureThreshold,
+		SuccessThreshold: 1,
+		CoolDown:         coolDown,
+	}
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.CoolDown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow reports whether a request may be made. Every request allowed must be followed by a call to record or release.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	from := b.state
+	err := b.admit()
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+	return err
+}
+
+// admit decides whether a request may be made, moving an open circuit to half-open
+// once CoolDown has passed. b.mu must be held.
+func (b *CircuitBreaker) admit() error {
+	if b.state == CircuitOpen {
+		if time.Since(b.openedAt) < b.CoolDown {
+			return ErrCircuitOpen
+		}
+		b.setState(CircuitHalfOpen)
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.trialInFlight {
+			return ErrCircuitOpen
+		}
+		b.trialInFlight = true
+	}
+
+	return nil
+}
+
+// record updates the circuit with the outcome of a request that was allowed.
+func (b *CircuitBreaker) record(success bool) {
+	b.mu.Lock()
+	from := b.state
+
+	switch b.state {
+	case CircuitClosed:
+		if success {
+			b.failures = 0
+		} else {
+			b.failures++
+			if b.failures >= b.FailureThreshold {
+				b.setState(CircuitOpen)
+			}
+		}
+
+	case CircuitHalfOpen:
+		b.trialInFlight = false
+		if success {
+			b.successes++
+			if b.successes >= b.SuccessThreshold {
+				b.setState(CircuitClosed)
+			}
+		} else {
+			b.setState(CircuitOpen)
+		}
+	}
+
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+// release ends a request that was allowed without recording its outcome, such as one whose call was
+// cancelled, so that it counts as neither a success nor a failure.
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.trialInFlight = false
+	}
+}
+
+// setState moves the circuit into state and resets its counters. b.mu must be held.
+func (b *CircuitBreaker) setState(state CircuitState) {
+	b.state = state
+	b.failures = 0
+	b.successes = 0
+	if state == CircuitOpen {
+		b.openedAt = time.Now()
+	}
+}
+
+// notify calls OnStateChange if the state has changed. It is called without b.mu held
+// so the callback is free to inspect the breaker.
+func (b *CircuitBreaker) notify(from, to CircuitState) {
+	if from != to && b.OnStateChange != nil {
+		b.OnStateChange(from, to)
+	}
+}
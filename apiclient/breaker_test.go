@@ -0,0 +1,163 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stateChange struct {
+	from, to CircuitState
+}
+
+// stateRecorder collects the state changes reported by a CircuitBreaker.
+type stateRecorder struct {
+	mu      sync.Mutex
+	changes []stateChange
+}
+
+func (r *stateRecorder) record(from, to CircuitState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, stateChange{from, to})
+}
+
+func (r *stateRecorder) get() []stateChange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]stateChange(nil), r.changes...)
+}
+
+func TestCircuitBreakerOpens(t *testing.T) {
+	var calls int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(500)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.CircuitBreaker = NewCircuitBreaker(3, time.Minute)
+	recorder := &stateRecorder{}
+	client.CircuitBreaker.OnStateChange = recorder.record
+
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Nil(t, accountData)
+	assert.Equal(t, ErrCircuitOpen, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, CircuitOpen, client.CircuitBreaker.State())
+
+	// Further calls are rejected without reaching the server.
+	err = Delete(client, "validAccountID", 0)
+	assert.Equal(t, ErrCircuitOpen, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	assert.Equal(t, []stateChange{{CircuitClosed, CircuitOpen}}, recorder.get())
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	var healthy int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.LoadInt32(&healthy) == 0 {
+			rw.WriteHeader(503)
+			return
+		}
+		rw.WriteHeader(204)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.CircuitBreaker = NewCircuitBreaker(1, 20*time.Millisecond)
+	recorder := &stateRecorder{}
+	client.CircuitBreaker.OnStateChange = recorder.record
+
+	// The first failure opens the circuit so the retry loop gives up with ErrCircuitOpen.
+	err := Delete(client, "validAccountID", 0)
+	assert.Equal(t, ErrCircuitOpen, err)
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, CircuitHalfOpen, client.CircuitBreaker.State())
+
+	// A successful trial request closes the circuit.
+	atomic.StoreInt32(&healthy, 1)
+	err = Delete(client, "validAccountID", 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CircuitClosed, client.CircuitBreaker.State())
+
+	changes := recorder.get()
+	assert.Equal(t, stateChange{CircuitClosed, CircuitOpen}, changes[0])
+	assert.Equal(t, stateChange{CircuitHalfOpen, CircuitClosed}, changes[len(changes)-1])
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(fetchHandler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.CircuitBreaker = NewCircuitBreaker(1, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		_, err := Fetch(client, "notFoundAccount")
+		assert.Equal(t, "status code not ok", err.Error())
+	}
+	assert.Equal(t, CircuitClosed, client.CircuitBreaker.State())
+}
+
+func TestCircuitBreakerIgnoresCancelledCalls(t *testing.T) {
+	var healthy int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.LoadInt32(&healthy) == 0 {
+			<-req.Context().Done()
+			return
+		}
+		rw.WriteHeader(204)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.CircuitBreaker = NewCircuitBreaker(1, 20*time.Millisecond)
+
+	// Calls cancelled by the caller do not open the circuit.
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := Delete(client, "validAccountID", 0, WithContext(ctx))
+		cancel()
+		assert.NotEqual(t, nil, err)
+		assert.NotEqual(t, ErrCircuitOpen, err)
+		assert.Equal(t, CircuitClosed, client.CircuitBreaker.State())
+	}
+
+	// A cancelled trial request in the half-open state lets another trial through.
+	client.CircuitBreaker.mu.Lock()
+	client.CircuitBreaker.setState(CircuitOpen)
+	client.CircuitBreaker.openedAt = time.Now().Add(-time.Minute)
+	client.CircuitBreaker.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	err := Delete(client, "validAccountID", 0, WithContext(ctx))
+	cancel()
+	assert.NotEqual(t, ErrCircuitOpen, err)
+	assert.Equal(t, CircuitHalfOpen, client.CircuitBreaker.State())
+
+	atomic.StoreInt32(&healthy, 1)
+	err = Delete(client, "validAccountID", 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CircuitClosed, client.CircuitBreaker.State())
+}
@@ -0,0 +1,195 @@
+package apiclient
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/rosalita/my-apiclient/apiclient/validation"
+)
+
+// countryCurrencies are the base currencies given to accounts built for each country supported by the Accounts API.
+var countryCurrencies = map[CountryCode]CurrencyCode{
+	CountryGB: CurrencyGBP, CountryAU: CurrencyAUD, CountryBE: CurrencyEUR, CountryCA: CurrencyCAD,
+	CountryFR: CurrencyEUR, CountryDE: CurrencyEUR, CountryGR: CurrencyEUR, CountryHK: CurrencyHKD,
+	CountryIT: CurrencyEUR, CountryLU: CurrencyEUR, CountryNL: CurrencyEUR, CountryPL: CurrencyPLN,
+	CountryPT: CurrencyEUR, CountryES: CurrencyEUR, CountryCH: CurrencyCHF, CountryUS: CurrencyUSD,
+}
+
+// AccountBuilder builds an AccountData for Create, filling in the type and id and the defaults for
+// the account's country. Its methods return the builder so that calls can be chained:
+//
+//	account, err := NewGBAccount(orgID).WithSortCode("400300").WithAccountNumber("41426819").WithBIC("NWBKGB22").Personal().Build()
+type AccountBuilder struct {
+	account   Account
+	validator Validator
+}
+
+// NewAccount starts building an account in country for an organisation.
+// The account's base currency and bank id code are set to the defaults for the country.
+func NewAccount(country CountryCode, organisationID string) *AccountBuilder {
+	b := &AccountBuilder{
+		account: Account{
+			AccountType:    "accounts",
+			OrganisationID: organisationID,
+			Attributes: AccountAttributes{
+				Country:      country,
+				BaseCurrency: countryCurrencies[country],
+			},
+		},
+	}
+	if rule, ok := countryRules[country]; ok {
+		b.account.Attributes.BankIDCode = rule.bankIDCode
+	}
+	return b
+}
+
+// NewGBAccount starts building a UK account for an organisation.
+func NewGBAccount(organisationID string) *AccountBuilder {
+	return NewAccount(CountryGB, organisationID)
+}
+
+// WithID sets the account's id. Without one Build generates a random UUID.
+func (b *AccountBuilder) WithID(id string) *AccountBuilder {
+	b.account.ID = id
+	return b
+}
+
+// WithBankID sets the account's bank id.
+func (b *AccountBuilder) WithBankID(bankID string) *AccountBuilder {
+	b.account.Attributes.BankID = bankID
+	return b
+}
+
+// WithSortCode sets the bank id of a UK account to its sort code.
+func (b *AccountBuilder) WithSortCode(sortCode string) *AccountBuilder {
+	return b.WithBankID(sortCode)
+}
+
+// WithBankIDCode replaces the default bank id code for the account's country.
+func (b *AccountBuilder) WithBankIDCode(code BankIDCode) *AccountBuilder {
+	b.account.Attributes.BankIDCode = code
+	return b
+}
+
+// WithAccountNumber sets the account number.
+func (b *AccountBuilder) WithAccountNumber(accountNumber string) *AccountBuilder {
+	b.account.Attributes.AccountNumber = accountNumber
+	return b
+}
+
+// WithBIC sets the BIC of the account's bank.
+func (b *AccountBuilder) WithBIC(bic string) *AccountBuilder {
+	b.account.Attributes.Bic = bic
+	return b
+}
+
+// WithIBAN sets the account's IBAN. Without one Build derives the IBAN of a UK account from
+// its BIC, sort code and account number.
+func (b *AccountBuilder) WithIBAN(iban string) *AccountBuilder {
+	b.account.Attributes.Iban = iban
+	return b
+}
+
+// WithBaseCurrency replaces the default base currency for the account's country.
+func (b *AccountBuilder) WithBaseCurrency(currency CurrencyCode) *AccountBuilder {
+	b.account.Attributes.BaseCurrency = currency
+	return b
+}
+
+// WithName sets the name of the account holder, in up to 4 lines.
+func (b *AccountBuilder) WithName(lines ...string) *AccountBuilder {
+	b.account.Attributes.Name = Some(append([]string{}, lines...))
+	return b
+}
+
+// WithAlternativeNames sets other names the account holder is known by.
+func (b *AccountBuilder) WithAlternativeNames(names ...string) *AccountBuilder {
+	b.account.Attributes.AlternativeNames = Some(append([]string{}, names...))
+	return b
+}
+
+// WithSecondaryIdentification sets the secondary identification of the account, such as a building society roll number.
+func (b *AccountBuilder) WithSecondaryIdentification(identification string) *AccountBuilder {
+	b.account.Attributes.SecondaryIdentification = Some(identification)
+	return b
+}
+
+// Personal classifies the account as belonging to an individual.
+func (b *AccountBuilder) Personal() *AccountBuilder {
+	b.account.Attributes.AccountClassification = AccountClassificationPersonal
+	return b
+}
+
+// Business classifies the account as belonging to an organisation.
+func (b *AccountBuilder) Business() *AccountBuilder {
+	b.account.Attributes.AccountClassification = AccountClassificationBusiness
+	return b
+}
+
+// Joint marks the account as held by more than one person.
+func (b *AccountBuilder) Joint() *AccountBuilder {
+	b.account.Attributes.JointAccount = Some(true)
+	return b
+}
+
+// MatchingOptOut opts the account out of account matching.
+func (b *AccountBuilder) MatchingOptOut() *AccountBuilder {
+	b.account.Attributes.AccountMatchingOptOut = Some(true)
+	return b
+}
+
+// ValidateWith sets the Validator used by Build, such as one with ModulusWeights to check UK account numbers.
+func (b *AccountBuilder) ValidateWith(validator Validator) *AccountBuilder {
+	b.validator = validator
+	return b
+}
+
+// Build returns the account, generating its id if none was set, and returns ValidationErrors if it is not valid.
+// The builder can be used again afterwards, such as to build a similar account.
+func (b *AccountBuilder) Build() (*AccountData, error) {
+	account := b.account
+	attributes := &account.Attributes
+	// Copy the slices so that later calls on the builder do not change the account built.
+	attributes.Name = copyStrings(attributes.Name)
+	attributes.AlternativeNames = copyStrings(attributes.AlternativeNames)
+
+	if account.ID == "" {
+		id, err := NewUUID()
+		if err != nil {
+			return nil, err
+		}
+		account.ID = id
+	}
+
+	if attributes.Country == CountryGB && attributes.Iban == "" && attributes.Bic != "" && attributes.BankID != "" && attributes.AccountNumber != "" {
+		// Invalid values are reported by Validate below.
+		if iban, err := validation.GBIBAN(attributes.Bic, attributes.BankID, attributes.AccountNumber); err == nil {
+			attributes.Iban = iban
+		}
+	}
+
+	accountData := &AccountData{Data: account}
+	if err := b.validator.Validate(accountData); err != nil {
+		return nil, err
+	}
+	return accountData, nil
+}
+
+// copyStrings returns values with its own copy of the slice it holds.
+func copyStrings(values Optional[[]string]) Optional[[]string] {
+	if value, ok := values.Get(); ok {
+		return Some(append([]string{}, value...))
+	}
+	return values
+}
+
+// NewUUID returns a random (version 4) UUID, such as for the id of an account or organisation.
+func NewUUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16]), nil
+}
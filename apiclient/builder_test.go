@@ -0,0 +1,125 @@
+package apiclient
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/rosalita/my-apiclient/apiclient/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountBuilder(t *testing.T) {
+	account, err := NewGBAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").
+		WithID("bd27e265-9605-4b4b-a0e5-3003ea9cc4dc").
+		WithSortCode("400300").
+		WithAccountNumber("41426819").
+		WithBIC("NWBKGB22").
+		WithName("Samantha Holder").
+		WithAlternativeNames("Sam Holder").
+		WithSecondaryIdentification("A1B2C3D4").
+		Personal().
+		Build()
+
+	expectedAccount := &AccountData{
+		Data: Account{
+			AccountType:    "accounts",
+			ID:             "bd27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+			Attributes: AccountAttributes{
+				Country:                 "GB",
+				BaseCurrency:            "GBP",
+				AccountNumber:           "41426819",
+				BankID:                  "400300",
+				BankIDCode:              "GBDSC",
+				Bic:                     "NWBKGB22",
+				Iban:                    "GB16NWBK40030041426819",
+				Name:                    Some([]string{"Samantha Holder"}),
+				AlternativeNames:        Some([]string{"Sam Holder"}),
+				SecondaryIdentification: Some("A1B2C3D4"),
+				AccountClassification:   "Personal",
+			},
+		},
+	}
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedAccount, account)
+}
+
+func TestAccountBuilderGeneratesIDs(t *testing.T) {
+	builder := NewAccount(CountryDE, "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").
+		WithBankID("37040044").
+		WithAccountNumber("0532013").
+		Business().
+		Joint().
+		MatchingOptOut()
+
+	first, err := builder.Build()
+	assert.Equal(t, nil, err)
+	second, err := builder.Build()
+	assert.Equal(t, nil, err)
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	assert.Regexp(t, uuid, first.Data.ID)
+	assert.NotEqual(t, first.Data.ID, second.Data.ID)
+
+	attributes := first.Data.Attributes
+	assert.Equal(t, CurrencyEUR, attributes.BaseCurrency)
+	assert.Equal(t, BankIDCodeDEBLZ, attributes.BankIDCode)
+	assert.Equal(t, Some(true), attributes.JointAccount)
+	assert.Equal(t, Some(true), attributes.AccountMatchingOptOut)
+}
+
+func TestAccountBuilderBuildsCopies(t *testing.T) {
+	builder := NewGBAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").
+		WithSortCode("400300").
+		WithAccountNumber("41426819").
+		WithBIC("NWBKGB22").
+		WithName("Samantha Holder").
+		WithAlternativeNames("Sam Holder")
+
+	first, err := builder.Build()
+	assert.Equal(t, nil, err)
+	first.Data.Attributes.Name.ValueOr(nil)[0] = "Changed"
+	first.Data.Attributes.AlternativeNames.ValueOr(nil)[0] = "Changed"
+
+	second, err := builder.WithAccountNumber("12345678").WithName("Jo Holder").Build()
+	assert.Equal(t, nil, err)
+	second.Data.Attributes.AlternativeNames.ValueOr(nil)[0] = "Jo"
+
+	third, err := builder.Build()
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "41426819", first.Data.Attributes.AccountNumber)
+	assert.Equal(t, Some([]string{"Changed"}), first.Data.Attributes.Name)
+	assert.Equal(t, Some([]string{"Jo Holder"}), second.Data.Attributes.Name)
+	assert.Equal(t, Some([]string{"Jo"}), second.Data.Attributes.AlternativeNames)
+	assert.Equal(t, "12345678", third.Data.Attributes.AccountNumber)
+	assert.Equal(t, Some([]string{"Jo Holder"}), third.Data.Attributes.Name)
+	assert.Equal(t, Some([]string{"Sam Holder"}), third.Data.Attributes.AlternativeNames)
+}
+
+func TestAccountBuilderValidationErrors(t *testing.T) {
+	account, err := NewGBAccount("").WithSortCode("4003").WithAccountNumber("41426819").Build()
+
+	assert.Nil(t, account)
+	assert.Equal(t, ValidationErrors{
+		{Field: "organisation_id", Message: "is required"},
+		{Field: "attributes.bank_id", Message: "must be 6 characters long for country GB"},
+		{Field: "attributes.bic", Message: "is required for country GB"},
+	}, err)
+}
+
+func TestAccountBuilderValidateWith(t *testing.T) {
+	weights, err := validation.LoadWeightTable(strings.NewReader("400000 409999 MOD11 0 0 0 0 0 0 8 7 6 5 4 3 2 1\n"))
+	assert.Equal(t, nil, err)
+
+	builder := NewGBAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").WithSortCode("400300").WithAccountNumber("12345678").WithBIC("NWBKGB22")
+	_, err = builder.Build()
+	assert.Equal(t, nil, err)
+
+	_, err = builder.ValidateWith(Validator{ModulusWeights: weights}).Build()
+	assert.Equal(t, ValidationErrors{
+		{Field: "attributes.account_number", Message: "is not valid for the sort code in bank_id"},
+	}, err)
+}
@@ -0,0 +1,152 @@
+package apiclient
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache stores the responses from Fetch, keyed by account id.
+// Values are the JSON response bodies so that a Cache can be backed by a store shared between processes.
+// Implementations must be safe for use by multiple goroutines.
+type Cache interface {
+	Get(accountID string) ([]byte, bool)
+	Set(accountID string, value []byte)
+	Delete(accountID string)
+}
+
+// LRUCache is an in-memory Cache holding a bounded number of entries for a fixed time.
+// When it is full the least recently used entry is evicted.
+type LRUCache struct {
+	size int
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// lruEntry is an entry in an LRUCache.
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache creates an LRUCache holding up to size entries, each for ttl.
+func NewLRUCache(size int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get returns the value stored for accountID if it is present and has not expired.
+func (c *LRUCache) Get(accountID string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[accountID]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*lruEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set stores value for accountID, evicting the least recently used entry if the cache is full.
+func (c *LRUCache) Set(accountID string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(c.ttl)
+
+	if elem, ok := c.entries[accountID]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[accountID] = c.order.PushFront(&lruEntry{key: accountID, value: value, expires: expires})
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete removes any value stored for accountID.
+func (c *LRUCache) Delete(accountID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[accountID]; ok {
+		c.remove(elem)
+	}
+}
+
+// Len returns the number of entries in the cache, including any which have expired but not yet been removed.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// remove deletes elem from the cache. c.mu must be held.
+func (c *LRUCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*lruEntry).key)
+}
+
+// cached returns the response body stored in the client's cache for accountID.
+func (c *Client) cached(accountID string) ([]byte, bool) {
+	if c.Cache == nil {
+		return nil, false
+	}
+	return c.Cache.Get(accountID)
+}
+
+// generation returns the number of times the client's cache has been invalidated.
+func (c *Client) generation() uint64 {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	return c.cacheGeneration
+}
+
+// store adds a fetched account to the client's cache, unless the cache has been invalidated since
+// generation was read, as the account may have been changed after it was fetched.
+func (c *Client) store(accountID string, body []byte, generation uint64) {
+	if c.Cache == nil {
+		return
+	}
+
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	if c.cacheGeneration == generation {
+		c.Cache.Set(accountID, body)
+	}
+}
+
+// invalidate removes an account from the client's caches after it has been changed through the client.
+func (c *Client) invalidate(accountID string) {
+	if c.Cache != nil {
+		c.cacheMu.Lock()
+		c.cacheGeneration++
+		c.Cache.Delete(accountID)
+		c.cacheMu.Unlock()
+	}
+	if c.ConditionalCache != nil {
+		c.ConditionalCache.Delete(accountID)
+	}
+}
@@ -0,0 +1,200 @@
+package apiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2, time.Minute)
+	cache.Set("a", []byte("A"))
+	cache.Set("b", []byte("B"))
+
+	// Reading a makes b the least recently used entry.
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("A"), value)
+
+	cache.Set("c", []byte("C"))
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+
+	cache.Delete("a")
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+}
+
+func TestLRUCacheExpiry(t *testing.T) {
+	cache := NewLRUCache(2, 10*time.Millisecond)
+	cache.Set("a", []byte("A"))
+
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+
+	time.Sleep(20 * time.Millisecond)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+// countingHandler counts the requests for each method before passing them on to handler.
+func countingHandler(counts map[string]*int32, handler http.HandlerFunc) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(counts[req.Method], 1)
+		handler(rw, req)
+	}
+}
+
+func TestFetchCached(t *testing.T) {
+	var gets, deletes int32
+	counts := map[string]*int32{"GET": &gets, "DELETE": &deletes}
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "DELETE" {
+			rw.WriteHeader(204)
+			return
+		}
+		fetchHandler(rw, req)
+	}
+	testServer := httptest.NewServer(countingHandler(counts, handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.Cache = NewLRUCache(10, time.Minute)
+
+	first, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	second, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&gets))
+
+	// Changing a cached account must not change the cache.
+	second.Data.Attributes.AlternativeBankAccountNames.ValueOr(nil)[0] = "Changed"
+	third, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, first, third)
+
+	// Errors are not cached.
+	_, err = Fetch(client, "notFoundAccount")
+	assert.NotNil(t, err)
+	_, err = Fetch(client, "notFoundAccount")
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&gets))
+
+	// Deleting the account through the client removes it from the cache.
+	err = Delete(client, "validAccountID", 0)
+	assert.Equal(t, nil, err)
+	_, err = Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&gets))
+}
+
+// mapCache is a Cache backed by a map, standing in for a cache provided by the caller.
+type mapCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (c *mapCache) Get(accountID string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.entries[accountID]
+	return value, ok
+}
+
+func (c *mapCache) Set(accountID string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[accountID] = value
+}
+
+func (c *mapCache) Delete(accountID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, accountID)
+}
+
+func TestCacheInvalidatedByUpdate(t *testing.T) {
+	var gets, patches int32
+	counts := map[string]*int32{"GET": &gets, "PATCH": &patches}
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "PATCH" {
+			updateHandler(rw, req)
+			return
+		}
+		fetchHandler(rw, req)
+	}
+	testServer := httptest.NewServer(countingHandler(counts, handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	cache := &mapCache{entries: map[string][]byte{}}
+	client.Cache = cache
+
+	_, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	_, ok := cache.Get("validAccountID")
+	assert.True(t, ok)
+
+	// The account is removed even when the update fails as the server may still have applied it.
+	account := &AccountData{Data: Account{ID: "validAccountID", Version: &zero}}
+	_, err = Update(client, account)
+	assert.NotNil(t, err)
+	_, ok = cache.Get("validAccountID")
+	assert.False(t, ok)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&patches))
+}
+
+func TestCacheNotFilledByFetchRacingDelete(t *testing.T) {
+	fetching := make(chan struct{})
+	deleted := make(chan struct{})
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "DELETE" {
+			rw.WriteHeader(204)
+			return
+		}
+		// The account is read before it is deleted, but the response arrives after.
+		close(fetching)
+		<-deleted
+		fetchHandler(rw, req)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	cache := &mapCache{entries: map[string][]byte{}}
+	client.Cache = cache
+
+	done := make(chan error)
+	go func() {
+		_, err := Fetch(client, "validAccountID")
+		done <- err
+	}()
+
+	<-fetching
+	err := Delete(client, "validAccountID", 0)
+	assert.Equal(t, nil, err)
+	close(deleted)
+
+	assert.Equal(t, nil, <-done)
+	_, ok := cache.Get("validAccountID")
+	assert.False(t, ok)
+}
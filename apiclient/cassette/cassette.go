@@ -0,0 +1,417 @@
+// Package cassette records the exchanges between an apiclient.Client and the Accounts API to a file,
+// and replays them so that tests can run without the Accounts API.
+package cassette
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/rosalita/my-apiclient/apiclient"
+)
+
+// ErrUnmatched is returned when replaying a request which does not match any recorded request.
+var ErrUnmatched = errors.New("cassette: no recorded interaction matches the request")
+
+// Redacted replaces the values of redacted JSON members.
+const Redacted = "REDACTED"
+
+// DefaultRedactFields are the JSON members holding personal or account details, which are
+// redacted from recordings unless a Recorder's RedactFields is changed.
+var DefaultRedactFields = []string{
+	"account_number", "alternative_bank_account_names", "alternative_names", "bank_account_name",
+	"first_name", "iban", "name", "organisation_identification", "private_identification",
+	"secondary_identification", "title",
+}
+
+// recordedHeaders are the response headers kept in recordings.
+var recordedHeaders = []string{"Content-Type", "ETag", "Last-Modified", "Retry-After"}
+
+// Mode is whether a Recorder records or replays.
+type Mode int
+
+// The modes of a Recorder.
+const (
+	// Record passes requests on to the Accounts API and saves the exchanges.
+	Record Mode = iota
+	// Replay answers requests from the saved exchanges.
+	Replay
+)
+
+// Request is a recorded request. The query is encoded with its parameters in order of name and
+// a JSON body is compacted with its members in order of name, so that requests can be matched exactly.
+type Request struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	// RawBody holds a body which is not JSON.
+	RawBody []byte `json:"raw_body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Status  int             `json:"status"`
+	Header  http.Header     `json:"header,omitempty"`
+	Body    json.RawMessage `json:"body,omitempty"`
+	RawBody []byte          `json:"raw_body,omitempty"`
+}
+
+// Interaction is a request and the response to it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Recorder is an http.RoundTripper which records or replays the requests of a Client.
+type Recorder struct {
+	// Transport makes requests when recording. It is the transport of the Client when the Recorder is attached.
+	Transport http.RoundTripper
+	// RedactFields are the JSON members whose values are replaced with Redacted in recordings,
+	// at any depth of the request and response bodies. Matching compares redacted requests.
+	RedactFields []string
+
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	unmatched    []Request
+}
+
+// New creates a Recorder for the cassette file at path. In Replay mode the file is loaded.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		RedactFields: append([]string(nil), DefaultRedactFields...),
+		path:         path,
+		mode:         mode,
+	}
+
+	if mode == Replay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("cassette %s: %v", path, err)
+		}
+		r.used = make([]bool, len(r.interactions))
+
+		// Bodies are put back in canonical form, as the file is indented and may have been edited.
+		for i := range r.interactions {
+			request := &r.interactions[i].Request
+			if request.Body != nil {
+				request.Body, request.RawBody = r.body(request.Body)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Attach makes the client send its requests through the Recorder. When recording, the client's
+// existing transport is used to make the requests.
+func (r *Recorder) Attach(client *apiclient.Client) {
+	if client.HTTPClient == nil {
+		client.HTTPClient = &http.Client{}
+	}
+	if r.Transport == nil {
+		r.Transport = client.HTTPClient.Transport
+	}
+	client.HTTPClient.Transport = r
+}
+
+// Stop saves the recorded interactions to the cassette file when recording.
+func (r *Recorder) Stop() error {
+	if r.mode != Record {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// Interactions returns the interactions recorded or loaded.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Unmatched returns the requests made while replaying which matched no interaction.
+func (r *Recorder) Unmatched() []Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Request(nil), r.unmatched...)
+}
+
+// Unused returns the loaded interactions which have not been replayed.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// RoundTrip records or replays a request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := r.request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == Replay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// Compressed bodies are recorded decompressed, so that they can be redacted.
+	body, err = decode(body, resp.Header.Get("Content-Encoding"))
+	if err != nil {
+		return nil, err
+	}
+
+	response := Response{Status: resp.StatusCode}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			if response.Header == nil {
+				response.Header = http.Header{}
+			}
+			response.Header.Set(name, value)
+		}
+	}
+	response.Body, response.RawBody = r.body(body)
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{Request: recorded, Response: response})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Interactions are replayed in the order they were recorded. Once every match has been
+	// replayed the last is repeated, as a request sent again gets the same response.
+	match := -1
+	for i, interaction := range r.interactions {
+		if !matches(interaction.Request, recorded) {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		r.unmatched = append(r.unmatched, recorded)
+		return nil, fmt.Errorf("%w: %s %s", ErrUnmatched, req.Method, req.URL.RequestURI())
+	}
+	r.used[match] = true
+
+	response := r.interactions[match].Response
+	body := []byte(response.Body)
+	if response.RawBody != nil {
+		body = response.RawBody
+	}
+
+	header := http.Header{}
+	for name, values := range response.Header {
+		header[name] = append([]string(nil), values...)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// request returns the recorded form of req, leaving its body to be read again.
+func (r *Recorder) request(req *http.Request) (Request, error) {
+	recorded := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return recorded, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	body, err = decode(body, req.Header.Get("Content-Encoding"))
+	if err != nil {
+		return recorded, err
+	}
+	recorded.Body, recorded.RawBody = r.body(body)
+	return recorded, nil
+}
+
+// decode undoes the content codings listed in contentEncoding. Bodies in codings other than gzip
+// and deflate cannot be redacted, so an error is returned rather than recording them.
+func decode(body []byte, contentEncoding string) ([]byte, error) {
+	if len(body) == 0 {
+		return body, nil
+	}
+
+	// Codings are listed in the order they were applied so are undone in reverse.
+	codings := strings.Split(contentEncoding, ",")
+	for i := len(codings) - 1; i >= 0; i-- {
+		coding := strings.ToLower(strings.TrimSpace(codings[i]))
+		var reader io.ReadCloser
+		var err error
+		switch coding {
+		case "", "identity":
+			continue
+		case "gzip":
+			reader, err = gzip.NewReader(bytes.NewReader(body))
+		case "deflate":
+			reader, err = zlib.NewReader(bytes.NewReader(body))
+		default:
+			return nil, fmt.Errorf("cassette: cannot redact a body with content encoding %q", coding)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cassette: decoding %s body: %v", coding, err)
+		}
+		body, err = ioutil.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cassette: decoding %s body: %v", coding, err)
+		}
+	}
+	return body, nil
+}
+
+// body returns a JSON body redacted and in canonical form, or a body which is not JSON as it is.
+func (r *Recorder) body(body []byte) (json.RawMessage, []byte) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, body
+	}
+
+	redact(value, r.RedactFields)
+
+	// Maps are marshalled with their keys in order, giving the canonical form.
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return nil, body
+	}
+	return canonical, nil
+}
+
+// redact replaces the values of the named members, at any depth of value, with Redacted.
+// Strings in arrays and objects held by the members are each replaced, keeping their shape.
+func redact(value interface{}, fields []string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, member := range v {
+			if containsString(fields, name) {
+				v[name] = redactValue(member)
+				continue
+			}
+			redact(member, fields)
+		}
+	case []interface{}:
+		for _, element := range v {
+			redact(element, fields)
+		}
+	}
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return Redacted
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactValue(element)
+		}
+		return v
+	case map[string]interface{}:
+		for name, member := range v {
+			v[name] = redactValue(member)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// matches reports whether a recorded request matches a request being replayed.
+func matches(recorded Request, req Request) bool {
+	return recorded.Method == req.Method &&
+		recorded.Path == req.Path &&
+		recorded.Query == req.Query &&
+		bytes.Equal(recorded.Body, req.Body) &&
+		bytes.Equal(recorded.RawBody, req.RawBody)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package cassette
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rosalita/my-apiclient/apiclient"
+	"github.com/rosalita/my-apiclient/apiclient/apiclienttest"
+	"github.com/stretchr/testify/assert"
+)
+
+const accountID = "bd27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+
+// lifecycle creates, fetches, lists and deletes an account, returning the fetched account.
+func lifecycle(t *testing.T, client *apiclient.Client) *apiclient.AccountData {
+	account, err := apiclient.NewGBAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").
+		WithID(accountID).
+		WithSortCode("400300").
+		WithAccountNumber("41426819").
+		WithBIC("NWBKGB22").
+		WithName("Samantha Holder").
+		Build()
+	assert.Equal(t, nil, err)
+
+	_, err = apiclient.Create(client, account)
+	assert.Equal(t, nil, err)
+
+	fetched, err := apiclient.Fetch(client, accountID)
+	assert.Equal(t, nil, err)
+
+	pageNum, pageSize := 0, 10
+	accountList, err := apiclient.List(client, &apiclient.ListParams{PageNum: &pageNum, PageSize: &pageSize})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(accountList.Data))
+
+	err = apiclient.Delete(client, accountID, 0)
+	assert.Equal(t, nil, err)
+
+	return fetched
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lifecycle.json")
+
+	server := apiclienttest.NewServer()
+	client := server.Client()
+	recorder, err := New(path, Record)
+	assert.Equal(t, nil, err)
+	recorder.Attach(client)
+
+	recorded := lifecycle(t, client)
+	assert.Equal(t, nil, recorder.Stop())
+	server.Close()
+	assert.Equal(t, 4, len(recorder.Interactions()))
+
+	// Personal and account details are redacted from the cassette.
+	data, err := ioutil.ReadFile(path)
+	assert.Equal(t, nil, err)
+	assert.NotContains(t, string(data), "41426819")
+	assert.NotContains(t, string(data), "Samantha Holder")
+	assert.Contains(t, string(data), `"account_number": "REDACTED"`)
+	assert.Contains(t, string(data), `"bank_id": "400300"`)
+
+	// The server is closed, so the replay can only be served from the cassette.
+	replayClient := apiclient.New(server.URL, 0, 0)
+	player, err := New(path, Replay)
+	assert.Equal(t, nil, err)
+	player.Attach(replayClient)
+
+	replayed := lifecycle(t, replayClient)
+	assert.Equal(t, recorded.Data.ID, replayed.Data.ID)
+	assert.Equal(t, "400300", replayed.Data.Attributes.BankID)
+	assert.Equal(t, Redacted, replayed.Data.Attributes.AccountNumber)
+	assert.Empty(t, player.Unused())
+	assert.Empty(t, player.Unmatched())
+}
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, zw.Close())
+	return buf.Bytes()
+}
+
+func TestRecordCompressedBodies(t *testing.T) {
+	var requestBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		zr, err := gzip.NewReader(req.Body)
+		assert.Equal(t, nil, err)
+		requestBody, _ = ioutil.ReadAll(zr)
+
+		rw.Header().Set("Content-Encoding", "gzip")
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write(gzipBytes(t, requestBody))
+	}))
+	defer server.Close()
+
+	client := apiclient.New(server.URL, 0, 0)
+	client.Compression = apiclient.NewCompression()
+	client.Compression.RequestThreshold = 1
+	recorder, err := New(filepath.Join(t.TempDir(), "compressed.json"), Record)
+	assert.Equal(t, nil, err)
+	recorder.Attach(client)
+
+	account, err := apiclient.NewGBAccount("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c").
+		WithID(accountID).
+		WithSortCode("400300").
+		WithAccountNumber("41426819").
+		WithBIC("NWBKGB22").
+		Build()
+	assert.Equal(t, nil, err)
+	created, err := apiclient.Create(client, account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "GB16NWBK40030041426819", created.Data.Attributes.Iban)
+	assert.Contains(t, string(requestBody), "GB16NWBK40030041426819")
+
+	// Both bodies are recorded decompressed and redacted.
+	interaction := recorder.Interactions()[0]
+	assert.Contains(t, string(interaction.Request.Body), `"iban":"REDACTED"`)
+	assert.Contains(t, string(interaction.Response.Body), `"iban":"REDACTED"`)
+	assert.Nil(t, interaction.Request.RawBody)
+	assert.Nil(t, interaction.Response.RawBody)
+	assert.Empty(t, interaction.Response.Header.Get("Content-Encoding"))
+}
+
+func TestRecordUnknownEncodingIsRefused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Encoding", "br")
+		rw.Write([]byte("compressed"))
+	}))
+	defer server.Close()
+
+	client := apiclient.New(server.URL, 0, 0)
+	recorder, err := New(filepath.Join(t.TempDir(), "br.json"), Record)
+	assert.Equal(t, nil, err)
+	recorder.Attach(client)
+
+	_, err = apiclient.Fetch(client, accountID)
+	assert.Contains(t, err.Error(), `cassette: cannot redact a body with content encoding "br"`)
+	assert.Empty(t, recorder.Interactions())
+}
+
+func TestReplayUnmatched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fetch.json")
+	cassette := `[{"request":{"method":"GET","path":"/v1/organisation/accounts/A"},` +
+		`"response":{"status":200,"body":{"data":{"type":"accounts","id":"A","organisation_id":"B","attributes":{"country":"GB"}}}}}]`
+	assert.Equal(t, nil, ioutil.WriteFile(path, []byte(cassette), 0644))
+
+	client := apiclient.New("http://accountapi.invalid", 0, 0)
+	player, err := New(path, Replay)
+	assert.Equal(t, nil, err)
+	player.Attach(client)
+
+	// A request can be replayed more than once.
+	for i := 0; i < 2; i++ {
+		account, err := apiclient.Fetch(client, "A")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "A", account.Data.ID)
+	}
+
+	_, err = apiclient.Fetch(client, "B")
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, "/v1/organisation/accounts/B", player.Unmatched()[0].Path)
+
+	_, err = player.RoundTrip(mustRequest(t, "DELETE", "http://accountapi.invalid/v1/organisation/accounts/A?version=0"))
+	assert.True(t, errors.Is(err, ErrUnmatched))
+}
+
+func TestMatchingIsCanonical(t *testing.T) {
+	recorder := &Recorder{RedactFields: DefaultRedactFields}
+
+	first, err := recorder.request(mustRequestWithBody(t, "POST", "http://host/path?b=2&a=1", `{"b":1, "a":{"iban":"GB16NWBK40030041426819"}}`))
+	assert.Equal(t, nil, err)
+	second, err := recorder.request(mustRequestWithBody(t, "POST", "http://host/path?a=1&b=2", `{"a":{"iban":"DE89370400440532013000"},"b":1}`))
+	assert.Equal(t, nil, err)
+
+	assert.True(t, matches(first, second))
+	assert.Equal(t, `{"a":{"iban":"REDACTED"},"b":1}`, string(first.Body))
+	assert.Equal(t, "a=1&b=2", first.Query)
+
+	third, err := recorder.request(mustRequestWithBody(t, "POST", "http://host/path?a=1&b=2", `{"a":{},"b":2}`))
+	assert.Equal(t, nil, err)
+	assert.False(t, matches(first, third))
+}
+
+func TestNewReplayMissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), Replay)
+	assert.NotEqual(t, nil, err)
+}
+
+func mustRequest(t *testing.T, method string, url string) *http.Request {
+	req, err := http.NewRequest(method, url, nil)
+	assert.Equal(t, nil, err)
+	return req
+}
+
+func mustRequestWithBody(t *testing.T, method string, url string, body string) *http.Request {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	assert.Equal(t, nil, err)
+	return req
+}
+
+func TestNewCopiesDefaultRedactFields(t *testing.T) {
+	defaults := append([]string(nil), DefaultRedactFields...)
+
+	recorder, err := New(filepath.Join(t.TempDir(), "fields.json"), Record)
+	assert.Equal(t, nil, err)
+	recorder.RedactFields[0] = "bank_id"
+	recorder.RedactFields = append(recorder.RedactFields[:1], "bic")
+
+	assert.Equal(t, defaults, DefaultRedactFields)
+}
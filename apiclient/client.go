@@ -1,12 +1,18 @@
 package apiclient
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"gopkg.in/retry.v1"
@@ -14,9 +20,58 @@ import (
 
 // Client is the type used to interface with the Accounts API.
 type Client struct {
-	BaseURL       string
-	HTTPClient    *http.Client
-	RetryStrategy retry.Strategy
+	BaseURL        string
+	HTTPClient     *http.Client
+	RetryStrategy  retry.Strategy
+	RateLimiter    *RateLimiter
+	CircuitBreaker *CircuitBreaker
+
+	IdempotentRetryPolicy    *RetryPolicy
+	NonIdempotentRetryPolicy *RetryPolicy
+
+	// AttemptTimeout limits each attempt so a hung request is abandoned and retried.
+	AttemptTimeout time.Duration
+	// OperationTimeout limits the time taken by a call including all of its retries.
+	OperationTimeout time.Duration
+
+	// HedgeDelay turns on hedging for GET requests. An attempt which has not completed after
+	// HedgeDelay is raced against a second identical attempt and the first success is used.
+	HedgeDelay time.Duration
+
+	// Cache stores the accounts returned by Fetch. Accounts created, updated or deleted
+	// through the client are removed from the cache.
+	Cache Cache
+
+	// DeduplicateFetches coalesces concurrent calls to Fetch for the same account into a single
+	// request whose result is shared by every caller. The options of the call which made the
+	// request apply to it, so cancelling that call's context fails the calls waiting on it too.
+	DeduplicateFetches bool
+
+	// ConditionalCache stores the ETag and Last-Modified validators sent with fetched accounts.
+	// When an account is held in it, Fetch asks the Accounts API for the account only if it has
+	// changed and returns the stored account when the response is 304 Not Modified.
+	ConditionalCache Cache
+
+	// Compression negotiates compressed responses and compresses large request bodies.
+	Compression *Compression
+
+	// Validator checks accounts before Create sends them.
+	Validator Validator
+	// DisableValidation stops Create checking accounts with the Validator before sending them.
+	DisableValidation bool
+
+	// LenientEnums lets enumerated values unknown to this version of apiclient, such as a new
+	// country code, through in the accounts sent and received rather than failing the call
+	// with an UnknownValueError.
+	LenientEnums bool
+
+	stats   clientStats
+	fetches flightGroup
+
+	// cacheMu guards cacheGeneration, which counts the invalidations of Cache so that a fetch
+	// which was in flight when its account was changed does not store the old account.
+	cacheMu         sync.Mutex
+	cacheGeneration uint64
 }
 
 // New creates a new instance of a Client.
@@ -33,56 +88,147 @@ func New(baseURL string, limitTimeout time.Duration, clientTimeout time.Duration
 		HTTPClient: &http.Client{
 			Timeout: clientTimeout,
 		},
-		RetryStrategy: strategy,
+		RetryStrategy:            strategy,
+		IdempotentRetryPolicy:    DefaultIdempotentRetryPolicy(),
+		NonIdempotentRetryPolicy: DefaultNonIdempotentRetryPolicy(),
 	}
 }
 
 // DoRequest makes a request to the Accounts API and handles the response.
-func (c *Client) DoRequest(method string, path string, params *ListParams, payload io.Reader) (body []byte, err error) {
+func (c *Client) DoRequest(method string, path string, params *ListParams, payload io.Reader, opts ...CallOption) (body []byte, err error) {
+	resp, err := c.do(method, path, params, payload, newCallOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+// do makes a request to the Accounts API, retrying as necessary, and returns the successful response.
+// As well as 200, 201 and 204 responses a 304 is successful, since it is only returned to conditional requests.
+func (c *Client) do(method string, path string, params *ListParams, payload io.Reader, options *callOptions) (*response, error) {
+	atomic.AddInt64(&c.stats.requests, 1)
+
+	ctx := options.ctx
+	if c.OperationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.OperationTimeout)
+		defer cancel()
+	}
+
+	policy := options.retryPolicy
+	if policy == nil {
+		policy = c.retryPolicy(method)
+	}
+
 	reqURL, err := url.Parse(c.BaseURL)
 	if err != nil {
 		return nil, err
 	}
 
+	// A query in path, such as the version of an account to delete, is kept as the query
+	// rather than escaped into the path.
 	reqURL.Path = path
+	if i := strings.Index(path, "?"); i >= 0 {
+		reqURL.Path, reqURL.RawQuery = path[:i], path[i+1:]
+	}
 
 	if params != nil {
-		query := url.Values{}
+		query := reqURL.Query()
 		if params.PageNum != nil {
-			query.Add("page[number]", string(*params.PageNum))
+			query.Add("page[number]", strconv.Itoa(*params.PageNum))
 		}
 		if params.PageSize != nil {
-			query.Add("page[size]", string(*params.PageSize))
+			query.Add("page[size]", strconv.Itoa(*params.PageSize))
+		}
+		if len(params.OrganisationIDs) > 0 {
+			query.Add("filter[organisation_id]", strings.Join(params.OrganisationIDs, ","))
 		}
 		reqURL.RawQuery = query.Encode()
 	}
 
-	req, err := http.NewRequest(method, reqURL.String(), payload)
-	if err != nil {
-		return nil, err
+	// The payload is read up front so that it can be sent again if the request is retried.
+	var data []byte
+	if payload != nil {
+		data, err = ioutil.ReadAll(payload)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	for r := retry.Start(c.RetryStrategy, nil); r.Next(); {
-		resp, err := c.HTTPClient.Do(req)
+	header := options.header
+	if c.Compression != nil && data != nil {
+		var compressed bool
+		data, compressed, err = c.Compression.compress(data)
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
+		if compressed {
+			header = header.Clone()
+			if header == nil {
+				header = http.Header{}
+			}
+			header.Set("Content-Encoding", "gzip")
+		}
+	}
 
-		switch resp.StatusCode {
-		case 429, 500, 503, 504:
-			log.Printf("Response Status %d Retrying request", resp.StatusCode)
-			continue
+	hedgeDelay := c.hedgeDelay(method, options)
+
+	for r := retry.StartWithCancel(c.RetryStrategy, nil, ctx.Done()); r.Next(); {
+		if r.Count() > 1 {
+			atomic.AddInt64(&c.stats.retries, 1)
+		}
 
-		case 200, 201:
-			body, err = ioutil.ReadAll(resp.Body)
-			if err != nil {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
 				return nil, err
 			}
-			return body, nil
+		}
 
-		case 204:
-			return nil, nil
+		if c.CircuitBreaker != nil {
+			if err := c.CircuitBreaker.allow(); err != nil {
+				return nil, err
+			}
+		}
+
+		atomic.AddInt64(&c.stats.attempts, 1)
+		var resp *response
+		if hedgeDelay > 0 {
+			resp, err = c.hedgedAttempt(ctx, method, reqURL.String(), header, data, hedgeDelay)
+		} else {
+			resp, err = c.attempt(ctx, method, reqURL.String(), header, data)
+		}
+		if c.CircuitBreaker != nil {
+			if err != nil && ctx.Err() != nil {
+				// The call was cancelled or timed out, which says nothing about the Accounts API.
+				c.CircuitBreaker.release()
+			} else {
+				c.CircuitBreaker.record(err == nil && resp.StatusCode < 500)
+			}
+		}
+		if err != nil {
+			if ctx.Err() == nil && policy.RetryError(err) {
+				log.Printf("Request error %v Retrying request", err)
+				continue
+			}
+			return nil, err
+		}
+
+		if resp.StatusCode == 429 && c.RateLimiter != nil {
+			c.RateLimiter.throttled()
+		}
+
+		if policy.RetryStatus(resp.StatusCode) {
+			log.Printf("Response Status %d Retrying request", resp.StatusCode)
+			continue
+		}
+
+		switch resp.StatusCode {
+		case 200, 201, 204, 304:
+			return resp, nil
 
 		default:
 			err = errors.New("status code not ok")
@@ -90,6 +236,61 @@ func (c *Client) DoRequest(method string, path string, params *ListParams, paylo
 		}
 	}
 
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
 	err = errors.New("retry timeout error")
 	return nil, err
 }
+
+// response is a response from the Accounts API with its body read in full.
+type response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// attempt makes a single request to the Accounts API and reads the response.
+// The attempt is abandoned if it takes longer than AttemptTimeout.
+func (c *Client) attempt(ctx context.Context, method string, reqURL string, header http.Header, data []byte) (*response, error) {
+	if c.AttemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.AttemptTimeout)
+		defer cancel()
+	}
+
+	var reqBody io.Reader
+	if data != nil {
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Compression != nil && len(c.Compression.Decoders) > 0 {
+		req.Header.Set("Accept-Encoding", c.Compression.acceptEncoding())
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := c.decodeBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}, nil
+}
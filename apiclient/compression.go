@@ -0,0 +1,136 @@
+package apiclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Decoder wraps a compressed response body in a reader which decompresses it.
+type Decoder func(body io.Reader) (io.ReadCloser, error)
+
+// Compression configures the compression of requests to and responses from the Accounts API.
+type Compression struct {
+	// Decoders are the content codings accepted in responses, keyed by name as used in
+	// the Accept-Encoding header. Add an entry such as "zstd" to accept other codings.
+	Decoders map[string]Decoder
+	// RequestThreshold is the size in bytes at or above which request bodies are sent gzip
+	// compressed. Zero sends every request body uncompressed.
+	RequestThreshold int
+}
+
+// NewCompression creates a Compression accepting gzip and deflate responses and sending
+// request bodies uncompressed.
+func NewCompression() *Compression {
+	return &Compression{
+		Decoders: defaultDecoders(),
+	}
+}
+
+func defaultDecoders() map[string]Decoder {
+	return map[string]Decoder{
+		"gzip": func(body io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(body)
+		},
+		"deflate": func(body io.Reader) (io.ReadCloser, error) {
+			return zlib.NewReader(body)
+		},
+	}
+}
+
+// acceptEncoding returns the Accept-Encoding header listing every coding that can be decoded.
+func (c *Compression) acceptEncoding() string {
+	codings := make([]string, 0, len(c.Decoders))
+	for coding := range c.Decoders {
+		codings = append(codings, coding)
+	}
+	sort.Strings(codings)
+	return strings.Join(codings, ", ")
+}
+
+// compress gzips data if it is at least RequestThreshold bytes long.
+// It reports whether the data was compressed.
+func (c *Compression) compress(data []byte) ([]byte, bool, error) {
+	if c.RequestThreshold <= 0 || len(data) < c.RequestThreshold {
+		return data, false, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, false, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, false, err
+	}
+	return buf.Bytes(), true, nil
+}
+
+// DecodeError is returned when a response body cannot be decompressed. It is not retried,
+// as the same body would be sent again.
+type DecodeError struct {
+	Coding string
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding %s response body: %v", e.Coding, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeBody reads a response body, undoing any content codings listed in its Content-Encoding header.
+// The net/http Transport only decompresses gzip responses itself when it chose the Accept-Encoding
+// header, so bodies are decoded here whenever a Content-Encoding is still present. Without a
+// Compression the gzip and deflate codings are still understood. Empty bodies, such as those of
+// 204 and 304 responses, are not decoded even if they have a Content-Encoding.
+func (c *Client) decodeBody(resp *http.Response) ([]byte, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	contentEncoding := resp.Header.Get("Content-Encoding")
+	if resp.Uncompressed || contentEncoding == "" || len(body) == 0 {
+		return body, nil
+	}
+
+	decoders := defaultDecoders()
+	if c.Compression != nil {
+		decoders = c.Compression.Decoders
+	}
+
+	// Codings are listed in the order they were applied so are undone in reverse.
+	codings := strings.Split(contentEncoding, ",")
+	for i := len(codings) - 1; i >= 0; i-- {
+		coding := strings.ToLower(strings.TrimSpace(codings[i]))
+		if coding == "identity" || coding == "" {
+			continue
+		}
+
+		decoder, ok := decoders[coding]
+		if !ok {
+			return nil, fmt.Errorf("unsupported content encoding %q", coding)
+		}
+
+		reader, err := decoder(bytes.NewReader(body))
+		if err != nil {
+			return nil, &DecodeError{Coding: coding, Err: err}
+		}
+		body, err = ioutil.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return nil, &DecodeError{Coding: coding, Err: err}
+		}
+	}
+
+	return body, nil
+}
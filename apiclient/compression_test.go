@@ -0,0 +1,217 @@
+package apiclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipBytes(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	return buf.Bytes()
+}
+
+func zlibBytes(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	return buf.Bytes()
+}
+
+func reverseBytes(data []byte) []byte {
+	reversed := make([]byte, len(data))
+	for i, b := range data {
+		reversed[len(data)-1-i] = b
+	}
+	return reversed
+}
+
+// encodingHandler serves fetchHandler's response encoded with coding, recording the Accept-Encoding sent.
+func encodingHandler(coding string, encode func([]byte) []byte, acceptEncoding *string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		*acceptEncoding = req.Header.Get("Accept-Encoding")
+
+		recorder := httptest.NewRecorder()
+		fetchHandler(recorder, req)
+
+		rw.Header().Set("Content-Encoding", coding)
+		rw.WriteHeader(recorder.Code)
+		rw.Write(encode(recorder.Body.Bytes()))
+	}
+}
+
+func TestCompressedResponses(t *testing.T) {
+	tests := []struct {
+		coding string
+		encode func([]byte) []byte
+	}{
+		{"gzip", gzipBytes},
+		{"deflate", zlibBytes},
+	}
+
+	for _, test := range tests {
+		var acceptEncoding string
+		testServer := httptest.NewServer(encodingHandler(test.coding, test.encode, &acceptEncoding))
+
+		limitTimeout := 10 * time.Millisecond
+		clientTimeout := 10 * time.Second
+		client := New(testServer.URL, limitTimeout, clientTimeout)
+		client.Compression = NewCompression()
+
+		accountData, err := Fetch(client, "validAccountID")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", accountData.Data.ID)
+		assert.Equal(t, "deflate, gzip", acceptEncoding)
+
+		testServer.Close()
+	}
+}
+
+func TestCompressedResponseWithDecompressionDisabled(t *testing.T) {
+	var acceptEncoding string
+	testServer := httptest.NewServer(encodingHandler("gzip", gzipBytes, &acceptEncoding))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.HTTPClient.Transport = &http.Transport{DisableCompression: true}
+
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", accountData.Data.ID)
+	assert.Equal(t, "", acceptEncoding)
+}
+
+func TestPluggableDecoder(t *testing.T) {
+	var acceptEncoding string
+	testServer := httptest.NewServer(encodingHandler("gzip, reverse", func(data []byte) []byte {
+		return reverseBytes(gzipBytes(data))
+	}, &acceptEncoding))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.Compression = NewCompression()
+	client.Compression.Decoders["reverse"] = func(body io.Reader) (io.ReadCloser, error) {
+		data, err := ioutil.ReadAll(body)
+		return ioutil.NopCloser(bytes.NewReader(reverseBytes(data))), err
+	}
+
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", accountData.Data.ID)
+	assert.Equal(t, "deflate, gzip, reverse", acceptEncoding)
+}
+
+func TestUnsupportedContentEncoding(t *testing.T) {
+	var acceptEncoding string
+	testServer := httptest.NewServer(encodingHandler("br", reverseBytes, &acceptEncoding))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.Compression = NewCompression()
+
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Nil(t, accountData)
+	assert.Equal(t, `unsupported content encoding "br"`, err.Error())
+}
+
+func TestEmptyResponseWithContentEncoding(t *testing.T) {
+	var requests int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		rw.Header().Set("Content-Encoding", "gzip")
+		rw.WriteHeader(204)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.Compression = NewCompression()
+
+	err := Delete(client, "validAccountID", 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestCorruptCompressedResponseNotRetried(t *testing.T) {
+	var requests int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		rw.Header().Set("Content-Encoding", "gzip")
+		rw.WriteHeader(200)
+		rw.Write(gzipBytes([]byte(`{"data":{}}`))[:10])
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 2 * time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.Compression = NewCompression()
+
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Nil(t, accountData)
+	assert.IsType(t, &DecodeError{}, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestCompressedRequests(t *testing.T) {
+	var contentEncodings []string
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		contentEncodings = append(contentEncodings, req.Header.Get("Content-Encoding"))
+		if req.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(req.Body)
+			if err != nil {
+				rw.WriteHeader(400)
+				return
+			}
+			req.Body = zr
+		}
+		createHandler(rw, req)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.Compression = NewCompression()
+	client.Compression.RequestThreshold = 100
+	client.DisableValidation = true
+
+	account := &AccountData{Data: Account{
+		ID:         "bd27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		Attributes: AccountAttributes{BankAccountName: Some(strings.Repeat("x", 100))},
+	}}
+	accountData, err := Create(client, account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "bd27e265-9605-4b4b-a0e5-3003ea9cc4dc", accountData.Data.ID)
+
+	// Small request bodies are sent as they are.
+	client.Compression.RequestThreshold = 10000
+	_, err = Create(client, account)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []string{"gzip", ""}, contentEncodings)
+}
@@ -0,0 +1,68 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// conditionalEntry is the entry stored in a client's ConditionalCache for an account.
+// It holds the validators sent with the account and the response body they describe.
+type conditionalEntry struct {
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"last_modified,omitempty"`
+	Body         json.RawMessage `json:"body"`
+}
+
+// conditional returns the entry stored in the client's ConditionalCache for accountID.
+func (c *Client) conditional(accountID string) (*conditionalEntry, bool) {
+	if c.ConditionalCache == nil {
+		return nil, false
+	}
+
+	value, ok := c.ConditionalCache.Get(accountID)
+	if !ok {
+		return nil, false
+	}
+
+	var entry conditionalEntry
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// storeConditional stores the validators and body of a fetched account in the client's ConditionalCache.
+// Responses without validators cannot be revalidated so are not stored.
+func (c *Client) storeConditional(accountID string, resp *response) {
+	if c.ConditionalCache == nil {
+		return
+	}
+
+	entry := conditionalEntry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Body:         resp.Body,
+	}
+	if entry.ETag == "" && entry.LastModified == "" {
+		c.ConditionalCache.Delete(accountID)
+		return
+	}
+
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	c.ConditionalCache.Set(accountID, value)
+}
+
+// header returns the headers which make a request conditional on the entry having changed.
+func (e *conditionalEntry) header() http.Header {
+	header := http.Header{}
+	if e.ETag != "" {
+		header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		header.Set("If-Modified-Since", e.LastModified)
+	}
+	return header
+}
@@ -0,0 +1,88 @@
+package apiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// etagHandler serves fetchHandler with an ETag and Last-Modified, answering 304 when the client's copy is current.
+func etagHandler(full, notModified *int32) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "DELETE" {
+			rw.WriteHeader(204)
+			return
+		}
+
+		if req.Header.Get("If-None-Match") == `"v1"` || req.Header.Get("If-Modified-Since") == "Wed, 15 Jan 2020 21:41:09 GMT" {
+			atomic.AddInt32(notModified, 1)
+			rw.WriteHeader(304)
+			return
+		}
+
+		atomic.AddInt32(full, 1)
+		rw.Header().Set("ETag", `"v1"`)
+		rw.Header().Set("Last-Modified", "Wed, 15 Jan 2020 21:41:09 GMT")
+		fetchHandler(rw, req)
+	}
+}
+
+func TestConditionalFetch(t *testing.T) {
+	var full, notModified int32
+	testServer := httptest.NewServer(etagHandler(&full, &notModified))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.ConditionalCache = NewLRUCache(10, time.Hour)
+
+	first, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&full))
+
+	second, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&full))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+
+	// Deleting the account through the client discards its validators.
+	err = Delete(client, "validAccountID", 0)
+	assert.Equal(t, nil, err)
+	_, err = Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&full))
+}
+
+func TestConditionalFetchNotModifiedWithoutCache(t *testing.T) {
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(304)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Nil(t, accountData)
+	assert.Equal(t, "status code not ok", err.Error())
+}
+
+func TestConditionalEntryHeader(t *testing.T) {
+	entry := &conditionalEntry{ETag: `"v1"`, LastModified: "Wed, 15 Jan 2020 21:41:09 GMT"}
+	header := entry.header()
+	assert.Equal(t, `"v1"`, header.Get("If-None-Match"))
+	assert.Equal(t, "Wed, 15 Jan 2020 21:41:09 GMT", header.Get("If-Modified-Since"))
+
+	entry = &conditionalEntry{LastModified: "Wed, 15 Jan 2020 21:41:09 GMT"}
+	header = entry.header()
+	assert.Equal(t, "", header.Get("If-None-Match"))
+	assert.Equal(t, "Wed, 15 Jan 2020 21:41:09 GMT", header.Get("If-Modified-Since"))
+}
@@ -1,30 +1,31 @@
 package apiclient
 
-import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-)
+// accountsPath is the path of the accounts resource in the Accounts API.
+const accountsPath = "/v1/organisation/accounts"
+
+// Create is the same as client.Create.
+func Create(client *Client, account *AccountData, opts ...CallOption) (*AccountData, error) {
+	return client.Create(account, opts...)
+}
 
 // Create registers an existing bank account or creates a new one.
-func Create(client *Client, account *AccountData) (*AccountData, error) {
-	jsonPayload, err := json.Marshal(account)
-	if err != nil {
-		return nil, err
+// The account is validated before it is sent unless the client's DisableValidation is set.
+// The client's LenientEnums also applies to validation.
+func (c *Client) Create(account *AccountData, opts ...CallOption) (*AccountData, error) {
+	if !c.DisableValidation {
+		validator := c.Validator
+		validator.LenientEnums = validator.LenientEnums || c.LenientEnums
+		if err := validator.Validate(account); err != nil {
+			return nil, err
+		}
 	}
 
-	path := fmt.Sprintf("/v1/organisation/accounts")
-
-	body, err := client.DoRequest("POST", path, nil, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return nil, err
-	}
+	defer c.invalidate(account.Data.ID)
 
-	var newAccount AccountData
-	err = json.Unmarshal(body, &newAccount)
+	newAccount, err := CreateResource(c, accountsPath, (*Document[Account])(account), opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &newAccount, nil
+	return (*AccountData)(newAccount), nil
 }
@@ -29,7 +29,7 @@ func createHandler(rw http.ResponseWriter, req *http.Request) {
 			`"bank_id":"400300",` +
 			`"bank_id_code":"GBDSC",` +
 			`"bic":"NWBKGB22",` +
-			`"iban":"GB11NWBK40030041426819",` +
+			`"iban":"GB16NWBK40030041426819",` +
 			`"title":"Ms",` +
 			`"first_name":"Samantha",` +
 			`"bank_account_name":"Samantha Holder",` +
@@ -70,15 +70,15 @@ func TestCreate(t *testing.T) {
 				BankID:                      "400300",
 				BankIDCode:                  "GBDSC",
 				Bic:                         "NWBKGB22",
-				Iban:                        "GB11NWBK40030041426819",
-				Title:                       "Ms",
-				FirstName:                   "Samantha",
-				BankAccountName:             "Samantha Holder",
-				AlternativeBankAccountNames: []string{"Sam Holder"},
+				Iban:                        "GB16NWBK40030041426819",
+				Title:                       Some("Ms"),
+				FirstName:                   Some("Samantha"),
+				BankAccountName:             Some("Samantha Holder"),
+				AlternativeBankAccountNames: Some([]string{"Sam Holder"}),
 				AccountClassification:       "Personal",
-				JointAccount:                false,
-				AccountMatchingOptOut:       false,
-				SecondaryIdentification:     "A1B2C3D4",
+				JointAccount:                Some(false),
+				AccountMatchingOptOut:       Some(false),
+				SecondaryIdentification:     Some("A1B2C3D4"),
 			},
 		},
 	}
@@ -96,15 +96,15 @@ func TestCreate(t *testing.T) {
 					BankID:                      "400300",
 					BankIDCode:                  "GBDSC",
 					Bic:                         "NWBKGB22",
-					Iban:                        "GB11NWBK40030041426819",
-					Title:                       "Ms",
-					FirstName:                   "Samantha",
-					BankAccountName:             "Samantha Holder",
-					AlternativeBankAccountNames: []string{"Sam Holder"},
+					Iban:                        "GB16NWBK40030041426819",
+					Title:                       Some("Ms"),
+					FirstName:                   Some("Samantha"),
+					BankAccountName:             Some("Samantha Holder"),
+					AlternativeBankAccountNames: Some([]string{"Sam Holder"}),
 					AccountClassification:       "Personal",
-					JointAccount:                false,
-					AccountMatchingOptOut:       false,
-					SecondaryIdentification:     "A1B2C3D4"},
+					JointAccount:                Some(false),
+					AccountMatchingOptOut:       Some(false),
+					SecondaryIdentification:     Some("A1B2C3D4")},
 			},
 		},
 	)
@@ -115,7 +115,12 @@ func TestCreate(t *testing.T) {
 		err         error
 	}{
 		{validPayload, &expectedAccount, nil},
-		{&AccountData{}, nil, errors.New("status code not ok")},
+		{&AccountData{}, nil, ValidationErrors{
+			{Field: "type", Message: "must be accounts"},
+			{Field: "id", Message: "is required"},
+			{Field: "organisation_id", Message: "is required"},
+			{Field: "attributes.country", Message: "is required"},
+		}},
 	}
 
 	// Create a new client and configure it to use test server instead of the real API endpoint.
@@ -130,4 +135,10 @@ func TestCreate(t *testing.T) {
 		assert.Equal(t, test.accountData, accountData)
 		assert.Equal(t, test.err, err)
 	}
+
+	// With validation disabled the invalid account is rejected by the Accounts API instead.
+	client.DisableValidation = true
+	accountData, err := Create(client, &AccountData{})
+	assert.Nil(t, accountData)
+	assert.Equal(t, errors.New("status code not ok"), err)
 }
@@ -4,13 +4,15 @@ import (
 	"fmt"
 )
 
-// Delete deletes an account.
-func Delete(client *Client, accountID string, version int) error {
-	path := fmt.Sprintf("/v1/organisation/accounts/%s?version=%d", accountID, version)
+// Delete is the same as client.Delete.
+func Delete(client *Client, accountID string, version int, opts ...CallOption) error {
+	return client.Delete(accountID, version, opts...)
+}
 
-	if _, err := client.DoRequest("DELETE", path, nil, nil); err != nil {
-		return err
-	}
+// Delete deletes an account.
+func (c *Client) Delete(accountID string, version int, opts ...CallOption) error {
+	path := fmt.Sprintf("%s/%s?version=%d", accountsPath, accountID, version)
+	defer c.invalidate(accountID)
 
-	return nil
+	return DeleteResource(c, path, opts...)
 }
@@ -12,22 +12,22 @@ import (
 
 func deleteHandler(rw http.ResponseWriter, req *http.Request) {
 	switch {
-	case req.URL.String() == "/v1/organisation/accounts/validAccountID%3Fversion=0" && req.Method == "DELETE":
+	case req.URL.String() == "/v1/organisation/accounts/validAccountID?version=0" && req.Method == "DELETE":
 		responseJSON := `{}`
 		rw.WriteHeader(204)
 		rw.Write([]byte(responseJSON))
 
-	case req.URL.String() == "/v1/organisation/accounts/notFoundAccount%3Fversion=0" && req.Method == "DELETE":
+	case req.URL.String() == "/v1/organisation/accounts/notFoundAccount?version=0" && req.Method == "DELETE":
 		responseJSON := `{"error_message":"record bd27e265-9605-4b4b-a0e5-3003ea9cc4dc does not exist"}`
 		rw.WriteHeader(404)
 		rw.Write([]byte(responseJSON))
 
-	case req.URL.String() == "/v1/organisation/accounts/internalServerError%3Fversion=0" && req.Method == "DELETE":
+	case req.URL.String() == "/v1/organisation/accounts/internalServerError?version=0" && req.Method == "DELETE":
 		responseJSON := `{"error_message":"internal server error"}`
 		rw.WriteHeader(500)
 		rw.Write([]byte(responseJSON))
 
-	case req.URL.String() == "/v1/organisation/accounts/missingVersion%3Fversion=0" && req.Method == "DELETE":
+	case req.URL.String() == "/v1/organisation/accounts/missingVersion?version=0" && req.Method == "DELETE":
 		responseJSON := `{"error_message":"version missing"}`
 		rw.WriteHeader(400)
 		rw.Write([]byte(responseJSON))
@@ -0,0 +1,133 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// Document is a JSON:API document holding a single resource, such as an Account.
+type Document[T any] struct {
+	Data     T                          `json:"data"`
+	Links    PageLinks                  `json:"links,omitzero"`
+	Meta     map[string]json.RawMessage `json:"meta,omitempty"`
+	Included []json.RawMessage          `json:"included,omitempty"`
+}
+
+// document and relationship have the fields of Document and Relationship without their JSON methods,
+// so that the methods can use the default encoding.
+type (
+	document[T any] Document[T]
+	relationship    Relationship
+)
+
+// MarshalJSON marshals the document, leaving out its links when it has none.
+func (d Document[T]) MarshalJSON() ([]byte, error) {
+	return marshalOmitZero(document[T](d))
+}
+
+// ListDocument is a JSON:API document holding a page of resources.
+type ListDocument[T any] struct {
+	Data     []T                        `json:"data"`
+	Links    PageLinks                  `json:"links"`
+	Meta     map[string]json.RawMessage `json:"meta,omitempty"`
+	Included []json.RawMessage          `json:"included,omitempty"`
+}
+
+// Relationship links a resource to other resources.
+type Relationship struct {
+	// Data is a resource identifier, an array of resource identifiers or null.
+	Data  json.RawMessage            `json:"data,omitempty"`
+	Links PageLinks                  `json:"links,omitzero"`
+	Meta  map[string]json.RawMessage `json:"meta,omitempty"`
+}
+
+// MarshalJSON marshals the relationship, leaving out its links when it has none.
+func (r Relationship) MarshalJSON() ([]byte, error) {
+	return marshalOmitZero(relationship(r))
+}
+
+// ResourceIdentifier identifies a resource by its type and id.
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Identifiers returns the resources the relationship links to, whether its data is a single
+// resource identifier or an array of them.
+func (r Relationship) Identifiers() ([]ResourceIdentifier, error) {
+	data := bytes.TrimSpace(r.Data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	if data[0] == '[' {
+		var identifiers []ResourceIdentifier
+		if err := json.Unmarshal(data, &identifiers); err != nil {
+			return nil, err
+		}
+		return identifiers, nil
+	}
+
+	var identifier ResourceIdentifier
+	if err := json.Unmarshal(data, &identifier); err != nil {
+		return nil, err
+	}
+	return []ResourceIdentifier{identifier}, nil
+}
+
+// CreateResource creates a resource by posting document to path, such as "/v1/organisation/accounts".
+func CreateResource[T any](client *Client, path string, document *Document[T], opts ...CallOption) (*Document[T], error) {
+	return request[Document[T]](client, "POST", path, nil, document, opts)
+}
+
+// FetchResource gets the resource at path, such as "/v1/organisation/accounts/{id}".
+func FetchResource[T any](client *Client, path string, opts ...CallOption) (*Document[T], error) {
+	return request[Document[T]](client, "GET", path, nil, nil, opts)
+}
+
+// ListResources gets a page of the resources at path, such as "/v1/organisation/accounts".
+func ListResources[T any](client *Client, path string, params *ListParams, opts ...CallOption) (*ListDocument[T], error) {
+	return request[ListDocument[T]](client, "GET", path, params, nil, opts)
+}
+
+// UpdateResource changes the resource at path, such as "/v1/organisation/accounts/{id}", by patching it with document.
+func UpdateResource[T any](client *Client, path string, document *Document[T], opts ...CallOption) (*Document[T], error) {
+	return request[Document[T]](client, "PATCH", path, nil, document, opts)
+}
+
+// DeleteResource deletes the resource at path, such as "/v1/organisation/accounts/{id}?version=0".
+func DeleteResource(client *Client, path string, opts ...CallOption) error {
+	_, err := client.DoRequest("DELETE", path, nil, nil, opts...)
+	return err
+}
+
+// request makes a request with payload marshalled as its body, if it is not nil,
+// and unmarshals the response body as a D.
+func request[D any](client *Client, method string, path string, params *ListParams, payload interface{}, opts []CallOption) (*D, error) {
+	var body io.Reader
+	if payload != nil {
+		if err := client.checkEnums(payload); err != nil {
+			return nil, err
+		}
+		jsonPayload, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(jsonPayload)
+	}
+
+	respBody, err := client.DoRequest(method, path, params, body, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var document D
+	if err := json.Unmarshal(respBody, &document); err != nil {
+		return nil, err
+	}
+	if err := client.checkEnums(&document); err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
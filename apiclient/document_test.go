@@ -0,0 +1,119 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// organisation is a resource other than an account, used to test the generic resource functions.
+type organisation struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		Name string `json:"name"`
+	} `json:"attributes"`
+}
+
+func organisationHandler(rw http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	switch {
+	case req.URL.Path == "/v1/organisation/units" && req.Method == "POST":
+		rw.WriteHeader(201)
+		rw.Write(body)
+
+	case req.URL.Path == "/v1/organisation/units" && req.Method == "GET":
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"data":[{"type":"organisations","id":"A","attributes":{"name":"Org A"}}],` +
+			`"links":{"first":"/first","last":"/last","self":"/self"},` +
+			`"meta":{"total":1},` +
+			`"included":[{"type":"accounts","id":"B"}]}`))
+
+	case req.URL.Path == "/v1/organisation/units/A" && req.Method == "GET":
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"data":{"type":"organisations","id":"A","attributes":{"name":"Org A"}},"links":{"self":"/v1/organisation/units/A"}}`))
+
+	case req.URL.Path == "/v1/organisation/units/A" && req.Method == "PATCH":
+		rw.WriteHeader(200)
+		rw.Write(body)
+
+	case req.URL.Path == "/v1/organisation/units/A" && req.Method == "DELETE":
+		rw.WriteHeader(204)
+
+	default:
+		rw.WriteHeader(404)
+	}
+}
+
+func TestGenericResources(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(organisationHandler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	var org organisation
+	org.Type = "organisations"
+	org.ID = "A"
+	org.Attributes.Name = "Org A"
+
+	created, err := CreateResource(client, "/v1/organisation/units", &Document[organisation]{Data: org})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, org, created.Data)
+
+	fetched, err := FetchResource[organisation](client, "/v1/organisation/units/A")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, org, fetched.Data)
+	assert.Equal(t, "/v1/organisation/units/A", fetched.Links.Self)
+
+	list, err := ListResources[organisation](client, "/v1/organisation/units", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []organisation{org}, list.Data)
+	assert.Equal(t, "/first", list.Links.First)
+	assert.Equal(t, json.RawMessage(`1`), list.Meta["total"])
+	assert.Equal(t, []json.RawMessage{json.RawMessage(`{"type":"accounts","id":"B"}`)}, list.Included)
+
+	org.Attributes.Name = "Org A Ltd"
+	updated, err := UpdateResource(client, "/v1/organisation/units/A", &Document[organisation]{Data: org})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Org A Ltd", updated.Data.Attributes.Name)
+
+	err = DeleteResource(client, "/v1/organisation/units/A")
+	assert.Equal(t, nil, err)
+
+	_, err = FetchResource[organisation](client, "/v1/organisation/units/B")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestAccountRelationships(t *testing.T) {
+	rawJSON := `{"data":{"type":"accounts","id":"A","organisation_id":"B","attributes":{},` +
+		`"relationships":{` +
+		`"master_account":{"data":[{"type":"accounts","id":"C"},{"type":"accounts","id":"D"}]},` +
+		`"account_events":{"data":{"type":"account_events","id":"E"}},` +
+		`"parent":{"data":null}` +
+		`}}}`
+
+	var account AccountData
+	err := json.Unmarshal([]byte(rawJSON), &account)
+	assert.Equal(t, nil, err)
+
+	identifiers, err := account.Data.Relationships["master_account"].Identifiers()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []ResourceIdentifier{{Type: "accounts", ID: "C"}, {Type: "accounts", ID: "D"}}, identifiers)
+
+	identifiers, err = account.Data.Relationships["account_events"].Identifiers()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []ResourceIdentifier{{Type: "account_events", ID: "E"}}, identifiers)
+
+	identifiers, err = account.Data.Relationships["parent"].Identifiers()
+	assert.Equal(t, nil, err)
+	assert.Nil(t, identifiers)
+	assert.Nil(t, account.Data.Extra)
+}
@@ -0,0 +1,161 @@
+package apiclient
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// UnknownValueError is returned for an enumerated value, such as a country code, which is not known.
+type UnknownValueError struct {
+	Kind  string
+	Value string
+}
+
+func (e *UnknownValueError) Error() string {
+	return fmt.Sprintf("unknown %s %q", e.Kind, e.Value)
+}
+
+// AccountClassification is the classification of an account.
+type AccountClassification string
+
+// The account classifications.
+const (
+	AccountClassificationPersonal AccountClassification = "Personal"
+	AccountClassificationBusiness AccountClassification = "Business"
+)
+
+// Valid reports whether c is a known account classification.
+func (c AccountClassification) Valid() bool {
+	return c == AccountClassificationPersonal || c == AccountClassificationBusiness
+}
+
+func (c AccountClassification) kind() string {
+	return "account classification"
+}
+
+// BankIDCode identifies the type of bank id used by an account.
+type BankIDCode string
+
+// The bank id codes supported by the Accounts API.
+const (
+	BankIDCodeAUBSB BankIDCode = "AUBSB" // Australia
+	BankIDCodeATBLZ BankIDCode = "ATBLZ" // Austria
+	BankIDCodeBE    BankIDCode = "BE"    // Belgium
+	BankIDCodeCACPA BankIDCode = "CACPA" // Canada
+	BankIDCodeFR    BankIDCode = "FR"    // France
+	BankIDCodeDEBLZ BankIDCode = "DEBLZ" // Germany
+	BankIDCodeGRBIC BankIDCode = "GRBIC" // Greece
+	BankIDCodeHKNCC BankIDCode = "HKNCC" // Hong Kong
+	BankIDCodeITNCC BankIDCode = "ITNCC" // Italy
+	BankIDCodeLU    BankIDCode = "LU"    // Luxembourg
+	BankIDCodePLKNR BankIDCode = "PLKNR" // Poland
+	BankIDCodePTNCC BankIDCode = "PTNCC" // Portugal
+	BankIDCodeESNCC BankIDCode = "ESNCC" // Spain
+	BankIDCodeCHBCC BankIDCode = "CHBCC" // Switzerland
+	BankIDCodeGBDSC BankIDCode = "GBDSC" // United Kingdom
+	BankIDCodeUSABA BankIDCode = "USABA" // United States
+)
+
+var bankIDCodes = map[BankIDCode]bool{
+	BankIDCodeAUBSB: true, BankIDCodeATBLZ: true, BankIDCodeBE: true, BankIDCodeCACPA: true,
+	BankIDCodeFR: true, BankIDCodeDEBLZ: true, BankIDCodeGRBIC: true, BankIDCodeHKNCC: true,
+	BankIDCodeITNCC: true, BankIDCodeLU: true, BankIDCodePLKNR: true, BankIDCodePTNCC: true,
+	BankIDCodeESNCC: true, BankIDCodeCHBCC: true, BankIDCodeGBDSC: true, BankIDCodeUSABA: true,
+}
+
+// Valid reports whether c is a known bank id code.
+func (c BankIDCode) Valid() bool {
+	return bankIDCodes[c]
+}
+
+func (c BankIDCode) kind() string {
+	return "bank id code"
+}
+
+// CountryCode is an ISO 3166-1 alpha-2 country code.
+type CountryCode string
+
+// Valid reports whether c is an ISO 3166-1 alpha-2 country code.
+func (c CountryCode) Valid() bool {
+	return countryCodes[c]
+}
+
+func (c CountryCode) kind() string {
+	return "country code"
+}
+
+// CurrencyCode is an ISO 4217 currency code.
+type CurrencyCode string
+
+// Valid reports whether c is an ISO 4217 currency code.
+func (c CurrencyCode) Valid() bool {
+	return currencyCodes[c]
+}
+
+func (c CurrencyCode) kind() string {
+	return "currency code"
+}
+
+// enum is implemented by the enumerated types.
+type enum interface {
+	Valid() bool
+	kind() string
+}
+
+// CheckEnums returns an UnknownValueError for the first enumerated value in v, such as the country
+// of an account, which is not known. Empty values are allowed as they mean the value is absent.
+// The client checks the accounts it sends and receives unless LenientEnums is set on it.
+func CheckEnums(v interface{}) error {
+	return checkEnums(reflect.ValueOf(v))
+}
+
+func checkEnums(v reflect.Value) error {
+	if !v.IsValid() {
+		return nil
+	}
+	if e, ok := v.Interface().(enum); ok {
+		if v.String() != "" && !e.Valid() {
+			return &UnknownValueError{Kind: e.kind(), Value: v.String()}
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return checkEnums(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if err := checkEnums(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := checkEnums(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := checkEnums(iter.Value()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkEnums checks the enumerated values in v unless the client is lenient.
+func (c *Client) checkEnums(v interface{}) error {
+	if c.LenientEnums {
+		return nil
+	}
+	return CheckEnums(v)
+}
@@ -0,0 +1,147 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumsJSON(t *testing.T) {
+	var attributes AccountAttributes
+	rawJSON := `{"country":"GB","base_currency":"GBP","bank_id_code":"GBDSC","account_classification":"Personal"}`
+	err := json.Unmarshal([]byte(rawJSON), &attributes)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CountryGB, attributes.Country)
+	assert.Equal(t, CurrencyGBP, attributes.BaseCurrency)
+	assert.Equal(t, BankIDCodeGBDSC, attributes.BankIDCode)
+	assert.Equal(t, AccountClassificationPersonal, attributes.AccountClassification)
+
+	data, err := json.Marshal(CurrencyEUR)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `"EUR"`, string(data))
+}
+
+func TestCheckEnums(t *testing.T) {
+	tests := []struct {
+		rawJSON string
+		err     error
+	}{
+		{`{"attributes":{"country":"UK"}}`, &UnknownValueError{Kind: "country code", Value: "UK"}},
+		{`{"attributes":{"base_currency":"gbp"}}`, &UnknownValueError{Kind: "currency code", Value: "gbp"}},
+		{`{"attributes":{"bank_id_code":"GBDCS"}}`, &UnknownValueError{Kind: "bank id code", Value: "GBDCS"}},
+		{`{"attributes":{"account_classification":"Private"}}`, &UnknownValueError{Kind: "account classification", Value: "Private"}},
+		{`{"attributes":{"private_identification":{"birth_country":"XX"}}}`, &UnknownValueError{Kind: "country code", Value: "XX"}},
+		{`{"attributes":{"organisation_identification":{"actors":[{"residency":"XX"}]}}}`, &UnknownValueError{Kind: "country code", Value: "XX"}},
+		{`{"attributes":{"country":"","base_currency":null,"nickname":"XX"}}`, nil},
+	}
+
+	for _, test := range tests {
+		var account Account
+		err := json.Unmarshal([]byte(test.rawJSON), &account)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, test.err, CheckEnums(&AccountData{Data: account}))
+	}
+
+	err := CheckEnums(AccountAttributes{Country: "XX"})
+	var unknown *UnknownValueError
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "unknown country code \"XX\"", unknown.Error())
+}
+
+func TestClientChecksEnums(t *testing.T) {
+	var patches int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "PATCH" {
+			atomic.AddInt32(&patches, 1)
+		}
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"data":{"id":"A","version":0,"attributes":{"country":"XK","account_classification":"Charity"}}}`))
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	_, err := Fetch(client, "A")
+	assert.Equal(t, &UnknownValueError{Kind: "country code", Value: "XK"}, err)
+
+	account := &AccountData{Data: Account{ID: "A", Version: &zero, Attributes: AccountAttributes{Country: "XK"}}}
+	_, err = Update(client, account)
+	assert.Equal(t, &UnknownValueError{Kind: "country code", Value: "XK"}, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&patches))
+
+	// A lenient client lets unknown values through, and other clients are unaffected.
+	lenient := New(testServer.URL, limitTimeout, clientTimeout)
+	lenient.LenientEnums = true
+
+	fetched, err := Fetch(lenient, "A")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CountryCode("XK"), fetched.Data.Attributes.Country)
+	assert.Equal(t, AccountClassification("Charity"), fetched.Data.Attributes.AccountClassification)
+
+	_, err = Update(lenient, account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&patches))
+
+	_, err = Fetch(client, "A")
+	assert.NotNil(t, err)
+}
+
+func TestCreateWithLenientEnums(t *testing.T) {
+	var posts int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&posts, 1)
+		body, _ := ioutil.ReadAll(req.Body)
+		rw.WriteHeader(201)
+		rw.Write(body)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	lenient := New(testServer.URL, limitTimeout, clientTimeout)
+	lenient.LenientEnums = true
+
+	account := accountIn(AccountAttributes{Country: "XX", BaseCurrency: "XXY", BankIDCode: "XXBANK", AccountClassification: "Charity"})
+	_, err := Create(client, account)
+	assert.Equal(t, ValidationErrors{
+		{Field: "attributes.country", Message: "must be an ISO 3166-1 alpha-2 country code"},
+		{Field: "attributes.base_currency", Message: "must be an ISO 4217 currency code"},
+		{Field: "attributes.account_classification", Message: "must be one of [Personal Business]"},
+		{Field: "attributes.bank_id_code", Message: "is not a known bank id code"},
+	}, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&posts))
+
+	created, err := Create(lenient, account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, CountryCode("XX"), created.Data.Attributes.Country)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&posts))
+
+	// The country is still required.
+	_, err = Create(lenient, accountIn(AccountAttributes{}))
+	assert.Equal(t, ValidationErrors{{Field: "attributes.country", Message: "is required"}}, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&posts))
+}
+
+func TestEnumSets(t *testing.T) {
+	assert.Equal(t, 249, len(countryCodes))
+	assert.Equal(t, 178, len(currencyCodes))
+
+	assert.True(t, CountryDE.Valid())
+	assert.False(t, CountryCode("EU").Valid())
+	assert.True(t, CurrencyCHF.Valid())
+	assert.False(t, CurrencyCode("EURO").Valid())
+	assert.True(t, BankIDCodeDEBLZ.Valid())
+	assert.False(t, BankIDCode("").Valid())
+}
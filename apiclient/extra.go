@@ -0,0 +1,158 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Fields of the JSON objects for Account and AccountAttributes, used to find the members
+// which are not known to this version of apiclient.
+var (
+	accountFields           = jsonFields(reflect.TypeOf(account{}))
+	accountAttributesFields = jsonFields(reflect.TypeOf(accountAttributes{}))
+)
+
+// account and accountAttributes have the fields of Account and AccountAttributes without their
+// JSON methods, so that the methods can use the default encoding.
+type (
+	account           Account
+	accountAttributes AccountAttributes
+)
+
+// UnmarshalJSON unmarshals an account, keeping any unknown members in Extra.
+func (a *Account) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(data, (*account)(a)); err != nil {
+		return err
+	}
+
+	extra, err := unknownMembers(data, accountFields)
+	a.Extra = extra
+	return err
+}
+
+// MarshalJSON marshals an account, including the members in Extra.
+func (a Account) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(account(a))
+	if err != nil {
+		return nil, err
+	}
+	return appendMembers(data, a.Extra, accountFields)
+}
+
+// UnmarshalJSON unmarshals account attributes, keeping any unknown members in Extra.
+func (a *AccountAttributes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(data, (*accountAttributes)(a)); err != nil {
+		return err
+	}
+
+	extra, err := unknownMembers(data, accountAttributesFields)
+	a.Extra = extra
+	return err
+}
+
+// MarshalJSON marshals account attributes, including the members in Extra.
+func (a AccountAttributes) MarshalJSON() ([]byte, error) {
+	data, err := marshalOmitZero(accountAttributes(a))
+	if err != nil {
+		return nil, err
+	}
+	return appendMembers(data, a.Extra, accountAttributesFields)
+}
+
+// jsonFields returns the lower case names of the JSON object members of struct type t.
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = true
+	}
+	return fields
+}
+
+// unknownMembers returns the members of the JSON object in data which are not in known.
+// It returns nil if every member is known.
+func unknownMembers(data []byte, known map[string]bool) (map[string]json.RawMessage, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	var extra map[string]json.RawMessage
+	for name, value := range members {
+		if isKnown(name, known) {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[name] = value
+	}
+	return extra, nil
+}
+
+// isKnown reports whether name is one of the known fields. Names are compared with Unicode
+// case folding, as encoding/json does, so that "ſtatus" is the same member as "status".
+func isKnown(name string, known map[string]bool) bool {
+	if known[strings.ToLower(name)] {
+		return true
+	}
+	for field := range known {
+		if strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendMembers adds the members in extra to the end of the JSON object in data, in order of name.
+// Members which are known are left out so that they cannot replace the fields of the struct.
+func appendMembers(data []byte, extra map[string]json.RawMessage, known map[string]bool) ([]byte, error) {
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		if !isKnown(name, known) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return data, nil
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, name := range names {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(extra[name])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,108 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountUnknownMembersRoundTrip(t *testing.T) {
+	rawJSON := `{` +
+		`"type":"accounts",` +
+		`"id":"A",` +
+		`"organisation_id":"B",` +
+		`"attributes":{"country":"GB",` +
+		`"marketing_opt_out":true,` +
+		`"nickname":{"short":"Sam"}` +
+		`},` +
+		`"created_on":"2020-01-01T00:00:00.000Z",` +
+		`"tags":["a","b"]` +
+		`}`
+
+	var account Account
+	err := json.Unmarshal([]byte(rawJSON), &account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]json.RawMessage{
+		"created_on": json.RawMessage(`"2020-01-01T00:00:00.000Z"`),
+		"tags":       json.RawMessage(`["a","b"]`),
+	}, account.Extra)
+	assert.Equal(t, map[string]json.RawMessage{
+		"marketing_opt_out": json.RawMessage(`true`),
+		"nickname":          json.RawMessage(`{"short":"Sam"}`),
+	}, account.Attributes.Extra)
+
+	marshalled, err := json.Marshal(&account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rawJSON, string(marshalled))
+}
+
+func TestKnownMembersNotExtra(t *testing.T) {
+	// encoding/json matches member names to fields without regard to case.
+	var account Account
+	err := json.Unmarshal([]byte(`{"ID":"A","Attributes":{"Country":"GB"}}`), &account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "A", account.ID)
+	assert.Equal(t, CountryCode("GB"), account.Attributes.Country)
+	assert.Nil(t, account.Extra)
+	assert.Nil(t, account.Attributes.Extra)
+}
+
+func TestExtraCannotReplaceKnownMembers(t *testing.T) {
+	account := Account{
+		ID: "A",
+		Extra: map[string]json.RawMessage{
+			"id":      json.RawMessage(`"B"`),
+			"created": json.RawMessage(`"today"`),
+		},
+	}
+
+	marshalled, err := json.Marshal(&account)
+	assert.Equal(t, nil, err)
+	assert.Contains(t, string(marshalled), `"id":"A"`)
+	assert.NotContains(t, string(marshalled), `"B"`)
+	assert.Contains(t, string(marshalled), `,"created":"today"}`)
+}
+
+func TestUpdateSendsUnknownMembers(t *testing.T) {
+	var received string
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		received = string(body)
+		rw.WriteHeader(200)
+		rw.Write(body)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	var account AccountData
+	err := json.Unmarshal([]byte(`{"data":{"id":"A","version":0,"attributes":{"country":"GB","nickname":"Sam"}}}`), &account)
+	assert.Equal(t, nil, err)
+
+	account.Data.Attributes.BankAccountName = Some("Samantha Smith")
+	updated, err := Update(client, &account)
+	assert.Equal(t, nil, err)
+	assert.Contains(t, received, `"nickname":"Sam"`)
+	assert.Equal(t, json.RawMessage(`"Sam"`), updated.Data.Attributes.Extra["nickname"])
+}
+
+func TestAccountMembersMatchedWithCaseFolding(t *testing.T) {
+	// encoding/json sets Status from "ſtatus", as ſ folds to s, so it is not an unknown member.
+	var attributes AccountAttributes
+	err := json.Unmarshal([]byte(`{"ſtatus":"closed","status":"pending","Country":"GB"}`), &attributes)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Some("pending"), attributes.Status)
+	assert.Equal(t, map[string]json.RawMessage(nil), attributes.Extra)
+
+	marshalled, err := json.Marshal(attributes)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"country":"GB","status":"pending"}`, string(marshalled))
+}
@@ -2,22 +2,74 @@ package apiclient
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
+// Fetch is the same as client.Fetch.
+func Fetch(client *Client, accountID string, opts ...CallOption) (*AccountData, error) {
+	return client.Fetch(accountID, opts...)
+}
+
 // Fetch gets a single account using the accountID.
-func Fetch(client *Client, accountID string) (*AccountData, error) {
-	path := fmt.Sprintf("/v1/organisation/accounts/%s", accountID)
+func (c *Client) Fetch(accountID string, opts ...CallOption) (*AccountData, error) {
+	generation := c.generation()
+	body, cached := c.cached(accountID)
+	if !cached {
+		fetch := func() ([]byte, error) {
+			return c.fetchBody(accountID, opts)
+		}
 
-	body, err := client.DoRequest("GET", path, nil, nil)
-	if err != nil {
-		return nil, err
+		var err error
+		if c.DeduplicateFetches {
+			body, err, _ = c.fetches.do(accountID, fetch)
+		} else {
+			body, err = fetch()
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var account AccountData
 	if err := json.Unmarshal(body, &account); err != nil {
 		return nil, err
 	}
+	if err := c.checkEnums(&account); err != nil {
+		return nil, err
+	}
+
+	if !cached {
+		c.store(accountID, body, generation)
+	}
 
 	return &account, nil
 }
+
+// fetchBody requests an account from the Accounts API. If the account is held in the client's
+// ConditionalCache the request is made conditional, and the stored body is returned when the
+// Accounts API responds that it has not been modified.
+func (c *Client) fetchBody(accountID string, opts []CallOption) ([]byte, error) {
+	path := fmt.Sprintf("%s/%s", accountsPath, accountID)
+	options := newCallOptions(opts)
+
+	entry, conditional := c.conditional(accountID)
+	if conditional {
+		options.header = entry.header()
+	}
+
+	resp, err := c.do("GET", path, nil, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == 304 {
+		if !conditional {
+			return nil, errors.New("status code not ok")
+		}
+		return entry.Body, nil
+	}
+
+	c.storeConditional(accountID, resp)
+	return resp.Body, nil
+}
@@ -67,14 +67,14 @@ func TestFetch(t *testing.T) {
 					BankIDCode:                  "GBDSC",
 					Bic:                         "NWBKGB22",
 					Iban:                        "GB11NWBK40030041426819",
-					Title:                       "Ms",
-					FirstName:                   "Samantha",
-					BankAccountName:             "Samantha Holder",
-					AlternativeBankAccountNames: []string{"Sam Holder"},
+					Title:                       Some("Ms"),
+					FirstName:                   Some("Samantha"),
+					BankAccountName:             Some("Samantha Holder"),
+					AlternativeBankAccountNames: Some([]string{"Sam Holder"}),
 					AccountClassification:       "Personal",
-					JointAccount:                false,
-					AccountMatchingOptOut:       false,
-					SecondaryIdentification:     "A1B2C3D4"},
+					JointAccount:                Some(false),
+					AccountMatchingOptOut:       Some(false),
+					SecondaryIdentification:     Some("A1B2C3D4")},
 			},
 		},
 	)
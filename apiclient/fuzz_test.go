@@ -0,0 +1,204 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Seeds for the account fuzz targets, taken from the fixtures of the other tests.
+var accountSeeds = []string{
+	`{"data":{"type":"A","id":"B","organisation_id":"C","Attributes":{"country":"DE","base_currency":"EUR",` +
+		`"account_number":"F","bank_id":"G","bank_id_code":"DEBLZ","bic":"I","iban":"J","title":"K","first_name":"L",` +
+		`"bank_account_name":"M","alternative_bank_account_names":["N","O"],"account_classification":"Business",` +
+		`"joint_account":true,"account_matching_opt_out":true,"secondary_identification":"Q"}}}`,
+	`{"data":{"type":"accounts","id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",` +
+		`"organisation_id":"eb0bd6f5-c3f5-44b2-b677-acd23cdde73c","version":0,"Attributes":{"country":"GB",` +
+		`"base_currency":"GBP","account_number":"41426819","bank_id":"400300","bank_id_code":"GBDSC","bic":"NWBKGB22",` +
+		`"iban":"GB11NWBK40030041426819","joint_account":false,"account_matching_opt_out":null}},` +
+		`"links":{"self":"/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}}`,
+	`{"data":{"type":"accounts","id":"A","organisation_id":"B","attributes":{"country":"GB","marketing_opt_out":true,` +
+		`"nickname":{"short":"Sam"}},"created_on":"2020-01-01T00:00:00.000Z","tags":["a","b"]}}`,
+	`{"data":{"type":"accounts","id":"A","organisation_id":"B","attributes":{},"relationships":{` +
+		`"master_account":{"data":[{"type":"accounts","id":"C"},{"type":"accounts","id":"D"}]},` +
+		`"account_events":{"data":{"type":"account_events","id":"E"}},"parent":{"data":null}}}}`,
+	`{"data":{"attributes":{"ſtatus":"b","status":"a"}}}`,
+	`{"data":null}`,
+	`{}`,
+}
+
+// Seeds for the account list fuzz target, taken from the fixtures of the other tests.
+var accountListSeeds = []string{
+	`{"data":[{"attributes":{"account_classification":"Personal","account_matching_opt_out":false,` +
+		`"account_number":"41426819","alternative_bank_account_names":["Sam Holder"],"bank_account_name":"Samantha Holder",` +
+		`"bank_id":"400300","bank_id_code":"GBDSC","base_currency":"GBP","bic":"NWBKGB22","country":"GB",` +
+		`"first_name":"Samantha","iban":"GB11NWBK40030041426819","joint_account":false,"secondary_identification":"A1B2C3D4",` +
+		`"title":"Ms"},"created_on":"2020-01-15T21:41:09.508Z","id":"bd27e265-9605-4b4b-a0e5-3003ea9cc4dc",` +
+		`"modified_on":"2020-01-15T21:41:09.508Z","organisation_id":"eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",` +
+		`"type":"accounts","version":0}],` +
+		`"links":{"first":"/v1/organisation/accounts?page%5Bnumber%5D=first&page%5Bsize%5D=2",` +
+		`"last":"/v1/organisation/accounts?page%5Bnumber%5D=last&page%5Bsize%5D=2",` +
+		`"self":"/v1/organisation/accounts?page%5Bnumber%5D=0&page%5Bsize%5D=2"}}`,
+	`{"data":[]}`,
+	`{"data":[null]}`,
+}
+
+func FuzzAccountDataRoundTrip(f *testing.F) {
+	for _, seed := range accountSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var account AccountData
+		if err := json.Unmarshal(data, &account); err != nil {
+			return
+		}
+		checkRoundTrip(t, &account, new(AccountData))
+	})
+}
+
+func FuzzAccountListDataRoundTrip(f *testing.F) {
+	for _, seed := range accountListSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var accountList AccountListData
+		if err := json.Unmarshal(data, &accountList); err != nil {
+			return
+		}
+		checkRoundTrip(t, &accountList, new(AccountListData))
+	})
+}
+
+// checkRoundTrip checks that decoded, once marshalled, unmarshals into empty
+// and marshals again to the same JSON.
+func checkRoundTrip(t *testing.T, decoded interface{}, empty interface{}) {
+	t.Helper()
+
+	first, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := json.Unmarshal(first, empty); err != nil {
+		t.Fatalf("unmarshal %s: %v", first, err)
+	}
+	second, err := json.Marshal(empty)
+	if err != nil {
+		t.Fatalf("marshal again: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Fatalf("round trip is not stable:\n%s\n%s", first, second)
+	}
+}
+
+// roundTripFunc is an http.RoundTripper which calls the function.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func FuzzListQuery(f *testing.F) {
+	f.Add(0, 2, "", "", true, true)
+	f.Add(1, 100, "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c", "", true, true)
+	f.Add(-1, 0, "a&b=c", "[]%+ ,#?", false, true)
+	f.Fuzz(func(t *testing.T, pageNum, pageSize int, org1, org2 string, hasPageNum, hasPageSize bool) {
+		params := &ListParams{}
+		if hasPageNum {
+			params.PageNum = &pageNum
+		}
+		if hasPageSize {
+			params.PageSize = &pageSize
+		}
+		for _, org := range []string{org1, org2} {
+			if org != "" {
+				params.OrganisationIDs = append(params.OrganisationIDs, org)
+			}
+		}
+
+		var rawQuery string
+		client := New("http://accountapi", 10*time.Millisecond, 10*time.Second)
+		client.HTTPClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			rawQuery = req.URL.RawQuery
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"data":[]}`)),
+				Request:    req,
+			}, nil
+		})
+		if _, err := client.List(params); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+
+		// Everything but the separators and percent escapes must be unreserved characters.
+		for _, c := range rawQuery {
+			if !strings.ContainsRune("&=%+", c) && !isUnreserved(c) {
+				t.Fatalf("query %q has unescaped %q", rawQuery, c)
+			}
+		}
+
+		query, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			t.Fatalf("parse query %q: %v", rawQuery, err)
+		}
+		checkPageParam(t, query, "page[number]", params.PageNum)
+		checkPageParam(t, query, "page[size]", params.PageSize)
+
+		filter, ok := query["filter[organisation_id]"]
+		if ok != (len(params.OrganisationIDs) > 0) {
+			t.Fatalf("query %q, organisation filter sent %v for %q", rawQuery, ok, params.OrganisationIDs)
+		}
+		if ok {
+			if len(filter) != 1 {
+				t.Fatalf("query %q has %d organisation filters", rawQuery, len(filter))
+			}
+			ids := strings.Split(filter[0], ",")
+			if !strings.Contains(org1+org2, ",") && !reflect.DeepEqual(ids, params.OrganisationIDs) {
+				t.Fatalf("query %q filters organisations %q, expected %q", rawQuery, ids, params.OrganisationIDs)
+			}
+		}
+
+		sent := 0
+		for _, key := range []string{"page[number]", "page[size]", "filter[organisation_id]"} {
+			if _, ok := query[key]; ok {
+				sent++
+			}
+		}
+		if len(query) != sent {
+			t.Fatalf("query %q has unexpected parameters", rawQuery)
+		}
+	})
+}
+
+// checkPageParam checks that query has the page parameter key if and only if value is set,
+// and that it decodes to value.
+func checkPageParam(t *testing.T, query url.Values, key string, value *int) {
+	t.Helper()
+
+	values, ok := query[key]
+	if ok != (value != nil) {
+		t.Fatalf("%s sent %v, expected %v", key, ok, value != nil)
+	}
+	if !ok {
+		return
+	}
+	if len(values) != 1 {
+		t.Fatalf("%s sent %d times", key, len(values))
+	}
+	number, err := strconv.Atoi(values[0])
+	if err != nil || number != *value {
+		t.Fatalf("%s is %q, expected %d", key, values[0], *value)
+	}
+}
+
+// isUnreserved reports whether c can appear in a URL without escaping, as defined by RFC 3986.
+func isUnreserved(c rune) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.ContainsRune("-._~", c)
+}
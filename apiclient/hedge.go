@@ -0,0 +1,77 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// WithHedgeDelay overrides the client's HedgeDelay for a call. A delay of zero turns hedging off.
+func WithHedgeDelay(delay time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.hedgeDelay = &delay
+	}
+}
+
+// hedgeDelay returns the delay after which a hedged attempt is sent, or zero if the request is not hedged.
+// Only GET requests are hedged as they are the only ones which are safe to have in flight twice.
+func (c *Client) hedgeDelay(method string, options *callOptions) time.Duration {
+	if method != "GET" {
+		return 0
+	}
+	if options.hedgeDelay != nil {
+		return *options.hedgeDelay
+	}
+	return c.HedgeDelay
+}
+
+// hedgedAttempt makes an attempt and, if it has not completed after delay, sends a second identical
+// attempt. The first successful response is returned and the other attempt is cancelled.
+// If both attempts fail the last failure is returned.
+func (c *Client) hedgedAttempt(ctx context.Context, method string, reqURL string, header http.Header, data []byte, delay time.Duration) (*response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		resp *response
+		err  error
+	}
+	results := make(chan result, 2)
+
+	send := func(hedge bool) {
+		if hedge && c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				results <- result{nil, err}
+				return
+			}
+		}
+		resp, err := c.attempt(ctx, method, reqURL, header, data)
+		results <- result{resp, err}
+	}
+
+	go send(false)
+	pending := 1
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			atomic.AddInt64(&c.stats.hedges, 1)
+			atomic.AddInt64(&c.stats.attempts, 1)
+			go send(true)
+			pending++
+
+		case res := <-results:
+			pending--
+			if res.err == nil && res.resp.StatusCode < 500 {
+				return res.resp, nil
+			}
+			if pending == 0 {
+				return res.resp, res.err
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package apiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// slowFirstHandler delays the first request until it is cancelled or 2 seconds have passed,
+// and answers later requests straight away with fetchHandler.
+func slowFirstHandler(calls *int32, cancelled *int32) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(calls, 1) == 1 {
+			select {
+			case <-req.Context().Done():
+				atomic.StoreInt32(cancelled, 1)
+				return
+			case <-time.After(2 * time.Second):
+			}
+		}
+		fetchHandler(rw, req)
+	}
+}
+
+func TestHedgedFetch(t *testing.T) {
+	var calls, cancelled int32
+	testServer := httptest.NewServer(slowFirstHandler(&calls, &cancelled))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.HedgeDelay = 20 * time.Millisecond
+
+	start := time.Now()
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", accountData.Data.ID)
+	assert.True(t, time.Since(start) < time.Second)
+
+	stats := client.Stats()
+	assert.Equal(t, int64(1), stats.Requests)
+	assert.Equal(t, int64(2), stats.Attempts)
+	assert.Equal(t, int64(1), stats.Hedges)
+
+	// The slow attempt is cancelled once the hedge has won.
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&cancelled) == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestHedgeNotSentForFastResponse(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(fetchHandler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.HedgeDelay = time.Second
+
+	_, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(0), client.Stats().Hedges)
+}
+
+func TestWithHedgeDelay(t *testing.T) {
+	var calls, cancelled int32
+	testServer := httptest.NewServer(slowFirstHandler(&calls, &cancelled))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	start := time.Now()
+	_, err := Fetch(client, "validAccountID", WithHedgeDelay(20*time.Millisecond))
+	assert.Equal(t, nil, err)
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, int64(1), client.Stats().Hedges)
+}
+
+func TestHedgeOnlyForGet(t *testing.T) {
+	var calls int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		rw.WriteHeader(204)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.HedgeDelay = 10 * time.Millisecond
+
+	err := Delete(client, "validAccountID", 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, int64(0), client.Stats().Hedges)
+}
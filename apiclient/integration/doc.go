@@ -0,0 +1,14 @@
+// Package integration holds tests which run against an Accounts API, such as the one started by
+// docker-compose. They are built with the integration build tag:
+//
+//	go test -tags integration ./apiclient/integration
+//
+// ACCOUNTAPI_URL sets the base URL of the Accounts API, which is http://localhost:8080 by default.
+// Setting it to "fake" runs the tests against the in-process fake from apiclienttest instead.
+// The tests are skipped when the Accounts API cannot be reached.
+//
+// Each test uses a new organisation id, so the tests need an Accounts API which supports PATCH and
+// applies the filter[organisation_id] list filter. The image in docker-compose.yml supports neither:
+// against it the Update steps of TestAccountLifecycle and TestConflicts fail, and TestPagination
+// fails its check that only the organisation's accounts are listed.
+package integration
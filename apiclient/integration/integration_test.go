@@ -0,0 +1,191 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rosalita/my-apiclient/apiclient"
+	"github.com/rosalita/my-apiclient/apiclient/apiclienttest"
+	"github.com/stretchr/testify/assert"
+)
+
+// baseURL is the base URL of the Accounts API the tests run against, and unavailable says why it cannot be reached.
+var (
+	baseURL     string
+	unavailable string
+)
+
+func TestMain(m *testing.M) {
+	baseURL = os.Getenv("ACCOUNTAPI_URL")
+	switch baseURL {
+	case "":
+		baseURL = "http://localhost:8080"
+	case "fake":
+		server := apiclienttest.NewServer()
+		baseURL = server.URL
+		code := m.Run()
+		server.Close()
+		os.Exit(code)
+	}
+
+	// Any response shows the Accounts API is there, whatever its status.
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	resp, err := httpClient.Get(baseURL + "/v1/organisation/accounts?page[size]=1")
+	if err != nil {
+		unavailable = fmt.Sprintf("Accounts API not available at %s: %v", baseURL, err)
+	} else {
+		resp.Body.Close()
+	}
+
+	os.Exit(m.Run())
+}
+
+// newClient returns a client for the Accounts API, skipping the test if it cannot be reached.
+func newClient(t *testing.T) *apiclient.Client {
+	t.Helper()
+	if unavailable != "" {
+		t.Skip(unavailable)
+	}
+	return apiclient.New(baseURL, 500*time.Millisecond, 10*time.Second)
+}
+
+// newOrganisation returns a new organisation id, so that each test only sees its own accounts.
+func newOrganisation(t *testing.T) string {
+	t.Helper()
+	id, err := apiclient.NewUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// createAccount creates a UK account for the organisation and deletes it when the test finishes.
+func createAccount(t *testing.T, client *apiclient.Client, organisationID string) *apiclient.AccountData {
+	t.Helper()
+
+	account, err := apiclient.NewGBAccount(organisationID).
+		WithSortCode("400300").
+		WithAccountNumber("41426819").
+		WithBIC("NWBKGB22").
+		WithSecondaryIdentification("A1B2C3D4").
+		Personal().
+		Build()
+	if !assert.Equal(t, nil, err) {
+		t.FailNow()
+	}
+
+	created, err := apiclient.Create(client, account)
+	if !assert.Equal(t, nil, err) {
+		t.FailNow()
+	}
+
+	t.Cleanup(func() {
+		if current, err := apiclient.Fetch(client, created.Data.ID); err == nil {
+			apiclient.Delete(client, current.Data.ID, *current.Data.Version)
+		}
+	})
+
+	return created
+}
+
+func TestAccountLifecycle(t *testing.T) {
+	client := newClient(t)
+	org := client.Organisation(newOrganisation(t))
+
+	created := createAccount(t, client, org.ID())
+	assert.Equal(t, 0, *created.Data.Version)
+	assert.Equal(t, org.ID(), created.Data.OrganisationID)
+	assert.Equal(t, "GB16NWBK40030041426819", created.Data.Attributes.Iban)
+
+	fetched, err := org.Fetch(created.Data.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, created.Data.Attributes.AccountNumber, fetched.Data.Attributes.AccountNumber)
+
+	accountList, err := org.List(nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{created.Data.ID}, apiclienttest.IDs(accountList))
+
+	fetched.Data.Attributes.SecondaryIdentification = apiclient.Some("E5F6G7H8")
+	updated, err := org.Update(fetched)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, *updated.Data.Version)
+	assert.Equal(t, apiclient.Some("E5F6G7H8"), updated.Data.Attributes.SecondaryIdentification)
+
+	err = org.Delete(created.Data.ID, *updated.Data.Version)
+	assert.Equal(t, nil, err)
+
+	_, err = org.Fetch(created.Data.ID)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestPagination(t *testing.T) {
+	client := newClient(t)
+	org := client.Organisation(newOrganisation(t))
+
+	expected := map[string]bool{}
+	for i := 0; i < 5; i++ {
+		expected[createAccount(t, client, org.ID()).Data.ID] = true
+	}
+
+	found := map[string]bool{}
+	pageSize := 2
+	for pageNum := 0; ; pageNum++ {
+		number := pageNum
+		accountList, err := org.List(&apiclient.ListParams{PageNum: &number, PageSize: &pageSize})
+		if !assert.Equal(t, nil, err) {
+			return
+		}
+		assert.True(t, len(accountList.Data) <= pageSize)
+
+		// The test relies on the Accounts API only listing the organisation's accounts.
+		for _, account := range accountList.Data {
+			if !assert.Equal(t, org.ID(), account.OrganisationID, "the organisation filter was not applied") {
+				return
+			}
+		}
+		for _, id := range apiclienttest.IDs(accountList) {
+			assert.False(t, found[id], "account %s listed twice", id)
+			found[id] = true
+		}
+		if accountList.Links.Next == "" || len(accountList.Data) == 0 {
+			break
+		}
+	}
+
+	assert.Equal(t, expected, found)
+}
+
+func TestConflicts(t *testing.T) {
+	client := newClient(t)
+	organisationID := newOrganisation(t)
+
+	created := createAccount(t, client, organisationID)
+
+	// An account cannot be created twice.
+	_, err := apiclient.Create(client, created)
+	assert.NotEqual(t, nil, err)
+
+	// Changes to an old version of an account are rejected.
+	stale := *created
+	version := 5
+	stale.Data.Version = &version
+	_, err = apiclient.Update(client, &stale)
+	assert.NotEqual(t, nil, err)
+
+	err = apiclient.Delete(client, created.Data.ID, version)
+	assert.NotEqual(t, nil, err)
+
+	fetched, err := apiclient.Fetch(client, created.Data.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, *fetched.Data.Version)
+
+	// Accounts of other organisations cannot be reached through an organisation's client.
+	_, err = client.Organisation(newOrganisation(t)).Fetch(created.Data.ID)
+	assert.Equal(t, apiclient.ErrOrganisationMismatch, err)
+}
@@ -0,0 +1,308 @@
+package apiclient
+
+// The ISO 3166-1 alpha-2 country codes.
+const (
+	CountryAD CountryCode = "AD"
+	CountryAE CountryCode = "AE"
+	CountryAF CountryCode = "AF"
+	CountryAG CountryCode = "AG"
+	CountryAI CountryCode = "AI"
+	CountryAL CountryCode = "AL"
+	CountryAM CountryCode = "AM"
+	CountryAO CountryCode = "AO"
+	CountryAQ CountryCode = "AQ"
+	CountryAR CountryCode = "AR"
+	CountryAS CountryCode = "AS"
+	CountryAT CountryCode = "AT"
+	CountryAU CountryCode = "AU"
+	CountryAW CountryCode = "AW"
+	CountryAX CountryCode = "AX"
+	CountryAZ CountryCode = "AZ"
+	CountryBA CountryCode = "BA"
+	CountryBB CountryCode = "BB"
+	CountryBD CountryCode = "BD"
+	CountryBE CountryCode = "BE"
+	CountryBF CountryCode = "BF"
+	CountryBG CountryCode = "BG"
+	CountryBH CountryCode = "BH"
+	CountryBI CountryCode = "BI"
+	CountryBJ CountryCode = "BJ"
+	CountryBL CountryCode = "BL"
+	CountryBM CountryCode = "BM"
+	CountryBN CountryCode = "BN"
+	CountryBO CountryCode = "BO"
+	CountryBQ CountryCode = "BQ"
+	CountryBR CountryCode = "BR"
+	CountryBS CountryCode = "BS"
+	CountryBT CountryCode = "BT"
+	CountryBV CountryCode = "BV"
+	CountryBW CountryCode = "BW"
+	CountryBY CountryCode = "BY"
+	CountryBZ CountryCode = "BZ"
+	CountryCA CountryCode = "CA"
+	CountryCC CountryCode = "CC"
+	CountryCD CountryCode = "CD"
+	CountryCF CountryCode = "CF"
+	CountryCG CountryCode = "CG"
+	CountryCH CountryCode = "CH"
+	CountryCI CountryCode = "CI"
+	CountryCK CountryCode = "CK"
+	CountryCL CountryCode = "CL"
+	CountryCM CountryCode = "CM"
+	CountryCN CountryCode = "CN"
+	CountryCO CountryCode = "CO"
+	CountryCR CountryCode = "CR"
+	CountryCU CountryCode = "CU"
+	CountryCV CountryCode = "CV"
+	CountryCW CountryCode = "CW"
+	CountryCX CountryCode = "CX"
+	CountryCY CountryCode = "CY"
+	CountryCZ CountryCode = "CZ"
+	CountryDE CountryCode = "DE"
+	CountryDJ CountryCode = "DJ"
+	CountryDK CountryCode = "DK"
+	CountryDM CountryCode = "DM"
+	CountryDO CountryCode = "DO"
+	CountryDZ CountryCode = "DZ"
+	CountryEC CountryCode = "EC"
+	CountryEE CountryCode = "EE"
+	CountryEG CountryCode = "EG"
+	CountryEH CountryCode = "EH"
+	CountryER CountryCode = "ER"
+	CountryES CountryCode = "ES"
+	CountryET CountryCode = "ET"
+	CountryFI CountryCode = "FI"
+	CountryFJ CountryCode = "FJ"
+	CountryFK CountryCode = "FK"
+	CountryFM CountryCode = "FM"
+	CountryFO CountryCode = "FO"
+	CountryFR CountryCode = "FR"
+	CountryGA CountryCode = "GA"
+	CountryGB CountryCode = "GB"
+	CountryGD CountryCode = "GD"
+	CountryGE CountryCode = "GE"
+	CountryGF CountryCode = "GF"
+	CountryGG CountryCode = "GG"
+	CountryGH CountryCode = "GH"
+	CountryGI CountryCode = "GI"
+	CountryGL CountryCode = "GL"
+	CountryGM CountryCode = "GM"
+	CountryGN CountryCode = "GN"
+	CountryGP CountryCode = "GP"
+	CountryGQ CountryCode = "GQ"
+	CountryGR CountryCode = "GR"
+	CountryGS CountryCode = "GS"
+	CountryGT CountryCode = "GT"
+	CountryGU CountryCode = "GU"
+	CountryGW CountryCode = "GW"
+	CountryGY CountryCode = "GY"
+	CountryHK CountryCode = "HK"
+	CountryHM CountryCode = "HM"
+	CountryHN CountryCode = "HN"
+	CountryHR CountryCode = "HR"
+	CountryHT CountryCode = "HT"
+	CountryHU CountryCode = "HU"
+	CountryID CountryCode = "ID"
+	CountryIE CountryCode = "IE"
+	CountryIL CountryCode = "IL"
+	CountryIM CountryCode = "IM"
+	CountryIN CountryCode = "IN"
+	CountryIO CountryCode = "IO"
+	CountryIQ CountryCode = "IQ"
+	CountryIR CountryCode = "IR"
+	CountryIS CountryCode = "IS"
+	CountryIT CountryCode = "IT"
+	CountryJE CountryCode = "JE"
+	CountryJM CountryCode = "JM"
+	CountryJO CountryCode = "JO"
+	CountryJP CountryCode = "JP"
+	CountryKE CountryCode = "KE"
+	CountryKG CountryCode = "KG"
+	CountryKH CountryCode = "KH"
+	CountryKI CountryCode = "KI"
+	CountryKM CountryCode = "KM"
+	CountryKN CountryCode = "KN"
+	CountryKP CountryCode = "KP"
+	CountryKR CountryCode = "KR"
+	CountryKW CountryCode = "KW"
+	CountryKY CountryCode = "KY"
+	CountryKZ CountryCode = "KZ"
+	CountryLA CountryCode = "LA"
+	CountryLB CountryCode = "LB"
+	CountryLC CountryCode = "LC"
+	CountryLI CountryCode = "LI"
+	CountryLK CountryCode = "LK"
+	CountryLR CountryCode = "LR"
+	CountryLS CountryCode = "LS"
+	CountryLT CountryCode = "LT"
+	CountryLU CountryCode = "LU"
+	CountryLV CountryCode = "LV"
+	CountryLY CountryCode = "LY"
+	CountryMA CountryCode = "MA"
+	CountryMC CountryCode = "MC"
+	CountryMD CountryCode = "MD"
+	CountryME CountryCode = "ME"
+	CountryMF CountryCode = "MF"
+	CountryMG CountryCode = "MG"
+	CountryMH CountryCode = "MH"
+	CountryMK CountryCode = "MK"
+	CountryML CountryCode = "ML"
+	CountryMM CountryCode = "MM"
+	CountryMN CountryCode = "MN"
+	CountryMO CountryCode = "MO"
+	CountryMP CountryCode = "MP"
+	CountryMQ CountryCode = "MQ"
+	CountryMR CountryCode = "MR"
+	CountryMS CountryCode = "MS"
+	CountryMT CountryCode = "MT"
+	CountryMU CountryCode = "MU"
+	CountryMV CountryCode = "MV"
+	CountryMW CountryCode = "MW"
+	CountryMX CountryCode = "MX"
+	CountryMY CountryCode = "MY"
+	CountryMZ CountryCode = "MZ"
+	CountryNA CountryCode = "NA"
+	CountryNC CountryCode = "NC"
+	CountryNE CountryCode = "NE"
+	CountryNF CountryCode = "NF"
+	CountryNG CountryCode = "NG"
+	CountryNI CountryCode = "NI"
+	CountryNL CountryCode = "NL"
+	CountryNO CountryCode = "NO"
+	CountryNP CountryCode = "NP"
+	CountryNR CountryCode = "NR"
+	CountryNU CountryCode = "NU"
+	CountryNZ CountryCode = "NZ"
+	CountryOM CountryCode = "OM"
+	CountryPA CountryCode = "PA"
+	CountryPE CountryCode = "PE"
+	CountryPF CountryCode = "PF"
+	CountryPG CountryCode = "PG"
+	CountryPH CountryCode = "PH"
+	CountryPK CountryCode = "PK"
+	CountryPL CountryCode = "PL"
+	CountryPM CountryCode = "PM"
+	CountryPN CountryCode = "PN"
+	CountryPR CountryCode = "PR"
+	CountryPS CountryCode = "PS"
+	CountryPT CountryCode = "PT"
+	CountryPW CountryCode = "PW"
+	CountryPY CountryCode = "PY"
+	CountryQA CountryCode = "QA"
+	CountryRE CountryCode = "RE"
+	CountryRO CountryCode = "RO"
+	CountryRS CountryCode = "RS"
+	CountryRU CountryCode = "RU"
+	CountryRW CountryCode = "RW"
+	CountrySA CountryCode = "SA"
+	CountrySB CountryCode = "SB"
+	CountrySC CountryCode = "SC"
+	CountrySD CountryCode = "SD"
+	CountrySE CountryCode = "SE"
+	CountrySG CountryCode = "SG"
+	CountrySH CountryCode = "SH"
+	CountrySI CountryCode = "SI"
+	CountrySJ CountryCode = "SJ"
+	CountrySK CountryCode = "SK"
+	CountrySL CountryCode = "SL"
+	CountrySM CountryCode = "SM"
+	CountrySN CountryCode = "SN"
+	CountrySO CountryCode = "SO"
+	CountrySR CountryCode = "SR"
+	CountrySS CountryCode = "SS"
+	CountryST CountryCode = "ST"
+	CountrySV CountryCode = "SV"
+	CountrySX CountryCode = "SX"
+	CountrySY CountryCode = "SY"
+	CountrySZ CountryCode = "SZ"
+	CountryTC CountryCode = "TC"
+	CountryTD CountryCode = "TD"
+	CountryTF CountryCode = "TF"
+	CountryTG CountryCode = "TG"
+	CountryTH CountryCode = "TH"
+	CountryTJ CountryCode = "TJ"
+	CountryTK CountryCode = "TK"
+	CountryTL CountryCode = "TL"
+	CountryTM CountryCode = "TM"
+	CountryTN CountryCode = "TN"
+	CountryTO CountryCode = "TO"
+	CountryTR CountryCode = "TR"
+	CountryTT CountryCode = "TT"
+	CountryTV CountryCode = "TV"
+	CountryTW CountryCode = "TW"
+	CountryTZ CountryCode = "TZ"
+	CountryUA CountryCode = "UA"
+	CountryUG CountryCode = "UG"
+	CountryUM CountryCode = "UM"
+	CountryUS CountryCode = "US"
+	CountryUY CountryCode = "UY"
+	CountryUZ CountryCode = "UZ"
+	CountryVA CountryCode = "VA"
+	CountryVC CountryCode = "VC"
+	CountryVE CountryCode = "VE"
+	CountryVG CountryCode = "VG"
+	CountryVI CountryCode = "VI"
+	CountryVN CountryCode = "VN"
+	CountryVU CountryCode = "VU"
+	CountryWF CountryCode = "WF"
+	CountryWS CountryCode = "WS"
+	CountryYE CountryCode = "YE"
+	CountryYT CountryCode = "YT"
+	CountryZA CountryCode = "ZA"
+	CountryZM CountryCode = "ZM"
+	CountryZW CountryCode = "ZW"
+)
+
+// countryCodes is the set of ISO 3166-1 alpha-2 country codes.
+var countryCodes = map[CountryCode]bool{
+	CountryAD: true, CountryAE: true, CountryAF: true, CountryAG: true, CountryAI: true,
+	CountryAL: true, CountryAM: true, CountryAO: true, CountryAQ: true, CountryAR: true,
+	CountryAS: true, CountryAT: true, CountryAU: true, CountryAW: true, CountryAX: true,
+	CountryAZ: true, CountryBA: true, CountryBB: true, CountryBD: true, CountryBE: true,
+	CountryBF: true, CountryBG: true, CountryBH: true, CountryBI: true, CountryBJ: true,
+	CountryBL: true, CountryBM: true, CountryBN: true, CountryBO: true, CountryBQ: true,
+	CountryBR: true, CountryBS: true, CountryBT: true, CountryBV: true, CountryBW: true,
+	CountryBY: true, CountryBZ: true, CountryCA: true, CountryCC: true, CountryCD: true,
+	CountryCF: true, CountryCG: true, CountryCH: true, CountryCI: true, CountryCK: true,
+	CountryCL: true, CountryCM: true, CountryCN: true, CountryCO: true, CountryCR: true,
+	CountryCU: true, CountryCV: true, CountryCW: true, CountryCX: true, CountryCY: true,
+	CountryCZ: true, CountryDE: true, CountryDJ: true, CountryDK: true, CountryDM: true,
+	CountryDO: true, CountryDZ: true, CountryEC: true, CountryEE: true, CountryEG: true,
+	CountryEH: true, CountryER: true, CountryES: true, CountryET: true, CountryFI: true,
+	CountryFJ: true, CountryFK: true, CountryFM: true, CountryFO: true, CountryFR: true,
+	CountryGA: true, CountryGB: true, CountryGD: true, CountryGE: true, CountryGF: true,
+	CountryGG: true, CountryGH: true, CountryGI: true, CountryGL: true, CountryGM: true,
+	CountryGN: true, CountryGP: true, CountryGQ: true, CountryGR: true, CountryGS: true,
+	CountryGT: true, CountryGU: true, CountryGW: true, CountryGY: true, CountryHK: true,
+	CountryHM: true, CountryHN: true, CountryHR: true, CountryHT: true, CountryHU: true,
+	CountryID: true, CountryIE: true, CountryIL: true, CountryIM: true, CountryIN: true,
+	CountryIO: true, CountryIQ: true, CountryIR: true, CountryIS: true, CountryIT: true,
+	CountryJE: true, CountryJM: true, CountryJO: true, CountryJP: true, CountryKE: true,
+	CountryKG: true, CountryKH: true, CountryKI: true, CountryKM: true, CountryKN: true,
+	CountryKP: true, CountryKR: true, CountryKW: true, CountryKY: true, CountryKZ: true,
+	CountryLA: true, CountryLB: true, CountryLC: true, CountryLI: true, CountryLK: true,
+	CountryLR: true, CountryLS: true, CountryLT: true, CountryLU: true, CountryLV: true,
+	CountryLY: true, CountryMA: true, CountryMC: true, CountryMD: true, CountryME: true,
+	CountryMF: true, CountryMG: true, CountryMH: true, CountryMK: true, CountryML: true,
+	CountryMM: true, CountryMN: true, CountryMO: true, CountryMP: true, CountryMQ: true,
+	CountryMR: true, CountryMS: true, CountryMT: true, CountryMU: true, CountryMV: true,
+	CountryMW: true, CountryMX: true, CountryMY: true, CountryMZ: true, CountryNA: true,
+	CountryNC: true, CountryNE: true, CountryNF: true, CountryNG: true, CountryNI: true,
+	CountryNL: true, CountryNO: true, CountryNP: true, CountryNR: true, CountryNU: true,
+	CountryNZ: true, CountryOM: true, CountryPA: true, CountryPE: true, CountryPF: true,
+	CountryPG: true, CountryPH: true, CountryPK: true, CountryPL: true, CountryPM: true,
+	CountryPN: true, CountryPR: true, CountryPS: true, CountryPT: true, CountryPW: true,
+	CountryPY: true, CountryQA: true, CountryRE: true, CountryRO: true, CountryRS: true,
+	CountryRU: true, CountryRW: true, CountrySA: true, CountrySB: true, CountrySC: true,
+	CountrySD: true, CountrySE: true, CountrySG: true, CountrySH: true, CountrySI: true,
+	CountrySJ: true, CountrySK: true, CountrySL: true, CountrySM: true, CountrySN: true,
+	CountrySO: true, CountrySR: true, CountrySS: true, CountryST: true, CountrySV: true,
+	CountrySX: true, CountrySY: true, CountrySZ: true, CountryTC: true, CountryTD: true,
+	CountryTF: true, CountryTG: true, CountryTH: true, CountryTJ: true, CountryTK: true,
+	CountryTL: true, CountryTM: true, CountryTN: true, CountryTO: true, CountryTR: true,
+	CountryTT: true, CountryTV: true, CountryTW: true, CountryTZ: true, CountryUA: true,
+	CountryUG: true, CountryUM: true, CountryUS: true, CountryUY: true, CountryUZ: true,
+	CountryVA: true, CountryVC: true, CountryVE: true, CountryVG: true, CountryVI: true,
+	CountryVN: true, CountryVU: true, CountryWF: true, CountryWS: true, CountryYE: true,
+	CountryYT: true, CountryZA: true, CountryZM: true, CountryZW: true,
+}
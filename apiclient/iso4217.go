@@ -0,0 +1,223 @@
+package apiclient
+
+// The ISO 4217 currency codes.
+const (
+	CurrencyAED CurrencyCode = "AED"
+	CurrencyAFN CurrencyCode = "AFN"
+	CurrencyALL CurrencyCode = "ALL"
+	CurrencyAMD CurrencyCode = "AMD"
+	CurrencyAOA CurrencyCode = "AOA"
+	CurrencyARS CurrencyCode = "ARS"
+	CurrencyAUD CurrencyCode = "AUD"
+	CurrencyAWG CurrencyCode = "AWG"
+	CurrencyAZN CurrencyCode = "AZN"
+	CurrencyBAM CurrencyCode = "BAM"
+	CurrencyBBD CurrencyCode = "BBD"
+	CurrencyBDT CurrencyCode = "BDT"
+	CurrencyBGN CurrencyCode = "BGN"
+	CurrencyBHD CurrencyCode = "BHD"
+	CurrencyBIF CurrencyCode = "BIF"
+	CurrencyBMD CurrencyCode = "BMD"
+	CurrencyBND CurrencyCode = "BND"
+	CurrencyBOB CurrencyCode = "BOB"
+	CurrencyBOV CurrencyCode = "BOV"
+	CurrencyBRL CurrencyCode = "BRL"
+	CurrencyBSD CurrencyCode = "BSD"
+	CurrencyBTN CurrencyCode = "BTN"
+	CurrencyBWP CurrencyCode = "BWP"
+	CurrencyBYN CurrencyCode = "BYN"
+	CurrencyBZD CurrencyCode = "BZD"
+	CurrencyCAD CurrencyCode = "CAD"
+	CurrencyCDF CurrencyCode = "CDF"
+	CurrencyCHE CurrencyCode = "CHE"
+	CurrencyCHF CurrencyCode = "CHF"
+	CurrencyCHW CurrencyCode = "CHW"
+	CurrencyCLF CurrencyCode = "CLF"
+	CurrencyCLP CurrencyCode = "CLP"
+	CurrencyCNY CurrencyCode = "CNY"
+	CurrencyCOP CurrencyCode = "COP"
+	CurrencyCOU CurrencyCode = "COU"
+	CurrencyCRC CurrencyCode = "CRC"
+	CurrencyCUP CurrencyCode = "CUP"
+	CurrencyCVE CurrencyCode = "CVE"
+	CurrencyCZK CurrencyCode = "CZK"
+	CurrencyDJF CurrencyCode = "DJF"
+	CurrencyDKK CurrencyCode = "DKK"
+	CurrencyDOP CurrencyCode = "DOP"
+	CurrencyDZD CurrencyCode = "DZD"
+	CurrencyEGP CurrencyCode = "EGP"
+	CurrencyERN CurrencyCode = "ERN"
+	CurrencyETB CurrencyCode = "ETB"
+	CurrencyEUR CurrencyCode = "EUR"
+	CurrencyFJD CurrencyCode = "FJD"
+	CurrencyFKP CurrencyCode = "FKP"
+	CurrencyGBP CurrencyCode = "GBP"
+	CurrencyGEL CurrencyCode = "GEL"
+	CurrencyGHS CurrencyCode = "GHS"
+	CurrencyGIP CurrencyCode = "GIP"
+	CurrencyGMD CurrencyCode = "GMD"
+	CurrencyGNF CurrencyCode = "GNF"
+	CurrencyGTQ CurrencyCode = "GTQ"
+	CurrencyGYD CurrencyCode = "GYD"
+	CurrencyHKD CurrencyCode = "HKD"
+	CurrencyHNL CurrencyCode = "HNL"
+	CurrencyHTG CurrencyCode = "HTG"
+	CurrencyHUF CurrencyCode = "HUF"
+	CurrencyIDR CurrencyCode = "IDR"
+	CurrencyILS CurrencyCode = "ILS"
+	CurrencyINR CurrencyCode = "INR"
+	CurrencyIQD CurrencyCode = "IQD"
+	CurrencyIRR CurrencyCode = "IRR"
+	CurrencyISK CurrencyCode = "ISK"
+	CurrencyJMD CurrencyCode = "JMD"
+	CurrencyJOD CurrencyCode = "JOD"
+	CurrencyJPY CurrencyCode = "JPY"
+	CurrencyKES CurrencyCode = "KES"
+	CurrencyKGS CurrencyCode = "KGS"
+	CurrencyKHR CurrencyCode = "KHR"
+	CurrencyKMF CurrencyCode = "KMF"
+	CurrencyKPW CurrencyCode = "KPW"
+	CurrencyKRW CurrencyCode = "KRW"
+	CurrencyKWD CurrencyCode = "KWD"
+	CurrencyKYD CurrencyCode = "KYD"
+	CurrencyKZT CurrencyCode = "KZT"
+	CurrencyLAK CurrencyCode = "LAK"
+	CurrencyLBP CurrencyCode = "LBP"
+	CurrencyLKR CurrencyCode = "LKR"
+	CurrencyLRD CurrencyCode = "LRD"
+	CurrencyLSL CurrencyCode = "LSL"
+	CurrencyLYD CurrencyCode = "LYD"
+	CurrencyMAD CurrencyCode = "MAD"
+	CurrencyMDL CurrencyCode = "MDL"
+	CurrencyMGA CurrencyCode = "MGA"
+	CurrencyMKD CurrencyCode = "MKD"
+	CurrencyMMK CurrencyCode = "MMK"
+	CurrencyMNT CurrencyCode = "MNT"
+	CurrencyMOP CurrencyCode = "MOP"
+	CurrencyMRU CurrencyCode = "MRU"
+	CurrencyMUR CurrencyCode = "MUR"
+	CurrencyMVR CurrencyCode = "MVR"
+	CurrencyMWK CurrencyCode = "MWK"
+	CurrencyMXN CurrencyCode = "MXN"
+	CurrencyMXV CurrencyCode = "MXV"
+	CurrencyMYR CurrencyCode = "MYR"
+	CurrencyMZN CurrencyCode = "MZN"
+	CurrencyNAD CurrencyCode = "NAD"
+	CurrencyNGN CurrencyCode = "NGN"
+	CurrencyNIO CurrencyCode = "NIO"
+	CurrencyNOK CurrencyCode = "NOK"
+	CurrencyNPR CurrencyCode = "NPR"
+	CurrencyNZD CurrencyCode = "NZD"
+	CurrencyOMR CurrencyCode = "OMR"
+	CurrencyPAB CurrencyCode = "PAB"
+	CurrencyPEN CurrencyCode = "PEN"
+	CurrencyPGK CurrencyCode = "PGK"
+	CurrencyPHP CurrencyCode = "PHP"
+	CurrencyPKR CurrencyCode = "PKR"
+	CurrencyPLN CurrencyCode = "PLN"
+	CurrencyPYG CurrencyCode = "PYG"
+	CurrencyQAR CurrencyCode = "QAR"
+	CurrencyRON CurrencyCode = "RON"
+	CurrencyRSD CurrencyCode = "RSD"
+	CurrencyRUB CurrencyCode = "RUB"
+	CurrencyRWF CurrencyCode = "RWF"
+	CurrencySAR CurrencyCode = "SAR"
+	CurrencySBD CurrencyCode = "SBD"
+	CurrencySCR CurrencyCode = "SCR"
+	CurrencySDG CurrencyCode = "SDG"
+	CurrencySEK CurrencyCode = "SEK"
+	CurrencySGD CurrencyCode = "SGD"
+	CurrencySHP CurrencyCode = "SHP"
+	CurrencySLE CurrencyCode = "SLE"
+	CurrencySOS CurrencyCode = "SOS"
+	CurrencySRD CurrencyCode = "SRD"
+	CurrencySSP CurrencyCode = "SSP"
+	CurrencySTN CurrencyCode = "STN"
+	CurrencySVC CurrencyCode = "SVC"
+	CurrencySYP CurrencyCode = "SYP"
+	CurrencySZL CurrencyCode = "SZL"
+	CurrencyTHB CurrencyCode = "THB"
+	CurrencyTJS CurrencyCode = "TJS"
+	CurrencyTMT CurrencyCode = "TMT"
+	CurrencyTND CurrencyCode = "TND"
+	CurrencyTOP CurrencyCode = "TOP"
+	CurrencyTRY CurrencyCode = "TRY"
+	CurrencyTTD CurrencyCode = "TTD"
+	CurrencyTWD CurrencyCode = "TWD"
+	CurrencyTZS CurrencyCode = "TZS"
+	CurrencyUAH CurrencyCode = "UAH"
+	CurrencyUGX CurrencyCode = "UGX"
+	CurrencyUSD CurrencyCode = "USD"
+	CurrencyUSN CurrencyCode = "USN"
+	CurrencyUYI CurrencyCode = "UYI"
+	CurrencyUYU CurrencyCode = "UYU"
+	CurrencyUYW CurrencyCode = "UYW"
+	CurrencyUZS CurrencyCode = "UZS"
+	CurrencyVED CurrencyCode = "VED"
+	CurrencyVES CurrencyCode = "VES"
+	CurrencyVND CurrencyCode = "VND"
+	CurrencyVUV CurrencyCode = "VUV"
+	CurrencyWST CurrencyCode = "WST"
+	CurrencyXAF CurrencyCode = "XAF"
+	CurrencyXAG CurrencyCode = "XAG"
+	CurrencyXAU CurrencyCode = "XAU"
+	CurrencyXBA CurrencyCode = "XBA"
+	CurrencyXBB CurrencyCode = "XBB"
+	CurrencyXBC CurrencyCode = "XBC"
+	CurrencyXBD CurrencyCode = "XBD"
+	CurrencyXCD CurrencyCode = "XCD"
+	CurrencyXCG CurrencyCode = "XCG"
+	CurrencyXDR CurrencyCode = "XDR"
+	CurrencyXOF CurrencyCode = "XOF"
+	CurrencyXPD CurrencyCode = "XPD"
+	CurrencyXPF CurrencyCode = "XPF"
+	CurrencyXPT CurrencyCode = "XPT"
+	CurrencyXSU CurrencyCode = "XSU"
+	CurrencyXTS CurrencyCode = "XTS"
+	CurrencyXUA CurrencyCode = "XUA"
+	CurrencyXXX CurrencyCode = "XXX"
+	CurrencyYER CurrencyCode = "YER"
+	CurrencyZAR CurrencyCode = "ZAR"
+	CurrencyZMW CurrencyCode = "ZMW"
+	CurrencyZWG CurrencyCode = "ZWG"
+)
+
+// currencyCodes is the set of ISO 4217 currency codes.
+var currencyCodes = map[CurrencyCode]bool{
+	CurrencyAED: true, CurrencyAFN: true, CurrencyALL: true, CurrencyAMD: true, CurrencyAOA: true,
+	CurrencyARS: true, CurrencyAUD: true, CurrencyAWG: true, CurrencyAZN: true, CurrencyBAM: true,
+	CurrencyBBD: true, CurrencyBDT: true, CurrencyBGN: true, CurrencyBHD: true, CurrencyBIF: true,
+	CurrencyBMD: true, CurrencyBND: true, CurrencyBOB: true, CurrencyBOV: true, CurrencyBRL: true,
+	CurrencyBSD: true, CurrencyBTN: true, CurrencyBWP: true, CurrencyBYN: true, CurrencyBZD: true,
+	CurrencyCAD: true, CurrencyCDF: true, CurrencyCHE: true, CurrencyCHF: true, CurrencyCHW: true,
+	CurrencyCLF: true, CurrencyCLP: true, CurrencyCNY: true, CurrencyCOP: true, CurrencyCOU: true,
+	CurrencyCRC: true, CurrencyCUP: true, CurrencyCVE: true, CurrencyCZK: true, CurrencyDJF: true,
+	CurrencyDKK: true, CurrencyDOP: true, CurrencyDZD: true, CurrencyEGP: true, CurrencyERN: true,
+	CurrencyETB: true, CurrencyEUR: true, CurrencyFJD: true, CurrencyFKP: true, CurrencyGBP: true,
+	CurrencyGEL: true, CurrencyGHS: true, CurrencyGIP: true, CurrencyGMD: true, CurrencyGNF: true,
+	CurrencyGTQ: true, CurrencyGYD: true, CurrencyHKD: true, CurrencyHNL: true, CurrencyHTG: true,
+	CurrencyHUF: true, CurrencyIDR: true, CurrencyILS: true, CurrencyINR: true, CurrencyIQD: true,
+	CurrencyIRR: true, CurrencyISK: true, CurrencyJMD: true, CurrencyJOD: true, CurrencyJPY: true,
+	CurrencyKES: true, CurrencyKGS: true, CurrencyKHR: true, CurrencyKMF: true, CurrencyKPW: true,
+	CurrencyKRW: true, CurrencyKWD: true, CurrencyKYD: true, CurrencyKZT: true, CurrencyLAK: true,
+	CurrencyLBP: true, CurrencyLKR: true, CurrencyLRD: true, CurrencyLSL: true, CurrencyLYD: true,
+	CurrencyMAD: true, CurrencyMDL: true, CurrencyMGA: true, CurrencyMKD: true, CurrencyMMK: true,
+	CurrencyMNT: true, CurrencyMOP: true, CurrencyMRU: true, CurrencyMUR: true, CurrencyMVR: true,
+	CurrencyMWK: true, CurrencyMXN: true, CurrencyMXV: true, CurrencyMYR: true, CurrencyMZN: true,
+	CurrencyNAD: true, CurrencyNGN: true, CurrencyNIO: true, CurrencyNOK: true, CurrencyNPR: true,
+	CurrencyNZD: true, CurrencyOMR: true, CurrencyPAB: true, CurrencyPEN: true, CurrencyPGK: true,
+	CurrencyPHP: true, CurrencyPKR: true, CurrencyPLN: true, CurrencyPYG: true, CurrencyQAR: true,
+	CurrencyRON: true, CurrencyRSD: true, CurrencyRUB: true, CurrencyRWF: true, CurrencySAR: true,
+	CurrencySBD: true, CurrencySCR: true, CurrencySDG: true, CurrencySEK: true, CurrencySGD: true,
+	CurrencySHP: true, CurrencySLE: true, CurrencySOS: true, CurrencySRD: true, CurrencySSP: true,
+	CurrencySTN: true, CurrencySVC: true, CurrencySYP: true, CurrencySZL: true, CurrencyTHB: true,
+	CurrencyTJS: true, CurrencyTMT: true, CurrencyTND: true, CurrencyTOP: true, CurrencyTRY: true,
+	CurrencyTTD: true, CurrencyTWD: true, CurrencyTZS: true, CurrencyUAH: true, CurrencyUGX: true,
+	CurrencyUSD: true, CurrencyUSN: true, CurrencyUYI: true, CurrencyUYU: true, CurrencyUYW: true,
+	CurrencyUZS: true, CurrencyVED: true, CurrencyVES: true, CurrencyVND: true, CurrencyVUV: true,
+	CurrencyWST: true, CurrencyXAF: true, CurrencyXAG: true, CurrencyXAU: true, CurrencyXBA: true,
+	CurrencyXBB: true, CurrencyXBC: true, CurrencyXBD: true, CurrencyXCD: true, CurrencyXCG: true,
+	CurrencyXDR: true, CurrencyXOF: true, CurrencyXPD: true, CurrencyXPF: true, CurrencyXPT: true,
+	CurrencyXSU: true, CurrencyXTS: true, CurrencyXUA: true, CurrencyXXX: true, CurrencyYER: true,
+	CurrencyZAR: true, CurrencyZMW: true, CurrencyZWG: true,
+}
@@ -1,28 +1,24 @@
 package apiclient
 
-import (
-	"encoding/json"
-)
-
 // ListParams are optional parameters used to call the List endpoint.
 type ListParams struct {
 	PageNum  *int
 	PageSize *int
+	// OrganisationIDs limits the accounts listed to those of the organisations.
+	OrganisationIDs []string
 }
 
-// List accepts optional parameters and lists all accounts.
-func List(client *Client, params *ListParams) (*AccountListData, error) {
-	path := "/v1/organisation/accounts"
+// List is the same as client.List.
+func List(client *Client, params *ListParams, opts ...CallOption) (*AccountListData, error) {
+	return client.List(params, opts...)
+}
 
-	body, err := client.DoRequest("GET", path, params, nil)
+// List accepts optional parameters and lists all accounts.
+func (c *Client) List(params *ListParams, opts ...CallOption) (*AccountListData, error) {
+	accountList, err := ListResources[Account](c, accountsPath, params, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	var accountList AccountListData
-	if err := json.Unmarshal(body, &accountList); err != nil {
-		return nil, err
-	}
-
-	return &accountList, nil
+	return (*AccountListData)(accountList), nil
 }
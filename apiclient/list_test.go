@@ -1,6 +1,7 @@
 package apiclient
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ var ( // Global variables referenced by tests requiring pointers to ints
 
 func listHandler(rw http.ResponseWriter, req *http.Request) {
 	switch {
-	case req.URL.String() == "/v1/organisation/accounts?page%5Bnumber%5D=%00&page%5Bsize%5D=%02" && req.Method == "GET":
+	case req.URL.String() == "/v1/organisation/accounts?page%5Bnumber%5D=0&page%5Bsize%5D=2" && req.Method == "GET":
 		responseJSON := `{"data":[{"attributes":{"account_classification":"Personal",` +
 			`"account_matching_opt_out":false,` +
 			`"account_number":"41426819",` +
@@ -68,12 +69,12 @@ func listHandler(rw http.ResponseWriter, req *http.Request) {
 		rw.WriteHeader(200)
 		rw.Write([]byte(responseJSON))
 
-	case req.URL.String() == "/v1/organisation/accounts?page%5Bnumber%5D=%00&page%5Bsize%5D=%01" && req.Method == "GET":
+	case req.URL.String() == "/v1/organisation/accounts?page%5Bnumber%5D=0&page%5Bsize%5D=1" && req.Method == "GET":
 		responseJSON := `{"error_message":"bad request"}`
 		rw.WriteHeader(400)
 		rw.Write([]byte(responseJSON))
 
-	case req.URL.String() == "/v1/organisation/accounts?page%5Bnumber%5D=%00&page%5Bsize%5D=%00" && req.Method == "GET":
+	case req.URL.String() == "/v1/organisation/accounts?page%5Bnumber%5D=0&page%5Bsize%5D=0" && req.Method == "GET":
 		responseJSON := `{"error_message":"internal server error"}`
 		rw.WriteHeader(500)
 		rw.Write([]byte(responseJSON))
@@ -88,6 +89,7 @@ func TestList(t *testing.T) {
 					AccountType:    "accounts",
 					ID:             "bd27e265-9605-4b4b-a0e5-3003ea9cc4dc",
 					OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+					Version:        &zero,
 					Attributes: AccountAttributes{
 						Country:                     "GB",
 						BaseCurrency:                "GBP",
@@ -96,18 +98,22 @@ func TestList(t *testing.T) {
 						BankIDCode:                  "GBDSC",
 						Bic:                         "NWBKGB22",
 						Iban:                        "GB11NWBK40030041426819",
-						Title:                       "Ms",
-						FirstName:                   "Samantha",
-						BankAccountName:             "Samantha Holder",
-						AlternativeBankAccountNames: []string{"Sam Holder"},
+						Title:                       Some("Ms"),
+						FirstName:                   Some("Samantha"),
+						BankAccountName:             Some("Samantha Holder"),
+						AlternativeBankAccountNames: Some([]string{"Sam Holder"}),
 						AccountClassification:       "Personal",
-						JointAccount:                false,
-						AccountMatchingOptOut:       false,
-						SecondaryIdentification:     "A1B2C3D4"}},
+						JointAccount:                Some(false),
+						AccountMatchingOptOut:       Some(false),
+						SecondaryIdentification:     Some("A1B2C3D4")},
+					Extra: map[string]json.RawMessage{
+						"created_on":  json.RawMessage(`"2020-01-15T21:41:09.508Z"`),
+						"modified_on": json.RawMessage(`"2020-01-15T21:41:09.508Z"`)}},
 				Account{
 					AccountType:    "accounts",
 					ID:             "cd27e265-9605-4b4b-a0e5-3003ea9cc4dc",
 					OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+					Version:        &zero,
 					Attributes: AccountAttributes{
 						Country:                     "GB",
 						BaseCurrency:                "GBP",
@@ -116,14 +122,17 @@ func TestList(t *testing.T) {
 						BankIDCode:                  "GBDSC",
 						Bic:                         "NWBKGB22",
 						Iban:                        "GB11NWBK40030041426819",
-						Title:                       "Ms",
-						FirstName:                   "Samantha",
-						BankAccountName:             "Samantha Holder",
-						AlternativeBankAccountNames: []string{"Sam Holder"},
+						Title:                       Some("Ms"),
+						FirstName:                   Some("Samantha"),
+						BankAccountName:             Some("Samantha Holder"),
+						AlternativeBankAccountNames: Some([]string{"Sam Holder"}),
 						AccountClassification:       "Personal",
-						JointAccount:                false,
-						AccountMatchingOptOut:       false,
-						SecondaryIdentification:     "A1B2C3D4"}}},
+						JointAccount:                Some(false),
+						AccountMatchingOptOut:       Some(false),
+						SecondaryIdentification:     Some("A1B2C3D4")},
+					Extra: map[string]json.RawMessage{
+						"created_on":  json.RawMessage(`"2020-01-16T20:01:25.633Z"`),
+						"modified_on": json.RawMessage(`"2020-01-16T20:01:25.633Z"`)}}},
 			Links: PageLinks{
 				First: "/v1/organisation/accounts?page%5Bnumber%5D=first\u0026page%5Bsize%5D=%02",
 				Last:  "/v1/organisation/accounts?page%5Bnumber%5D=last\u0026page%5Bsize%5D=%02",
@@ -0,0 +1,258 @@
+// Package mock provides a mock apiclient.AccountsService which records its calls and returns
+// the results set up by expectations.
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/rosalita/my-apiclient/apiclient"
+)
+
+// ErrUnexpectedCall is returned by calls which do not match an expectation.
+var ErrUnexpectedCall = errors.New("mock: unexpected call")
+
+// anything is the type of Anything, so that no argument of a call can be mistaken for it.
+type anything string
+
+// Anything matches any argument of an expectation.
+const Anything = anything("mock.Anything")
+
+// GoString formats Anything in failure messages.
+func (a anything) GoString() string {
+	return string(a)
+}
+
+// TestingT is the part of *testing.T used to report failures.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Call is a call made to the mock. Args are the arguments of the call without its CallOptions.
+type Call struct {
+	Method  string
+	Args    []interface{}
+	Options []apiclient.CallOption
+}
+
+// Expectation is a call the mock expects, and what it returns.
+type Expectation struct {
+	method  string
+	args    []interface{}
+	returns []interface{}
+	times   int
+	calls   int
+}
+
+// Return sets the values returned by calls matching the expectation, in the order they are
+// returned by the method. Nil can be given for any of them, but a value of the wrong type fails
+// the test when the method is called.
+func (e *Expectation) Return(values ...interface{}) *Expectation {
+	e.returns = values
+	return e
+}
+
+// Times limits the expectation to n calls. Without a limit it matches any number of calls.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// Once limits the expectation to a single call.
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+// AccountsService is a mock apiclient.AccountsService.
+type AccountsService struct {
+	t TestingT
+
+	mu           sync.Mutex
+	expectations []*Expectation
+	calls        []Call
+}
+
+var _ apiclient.AccountsService = (*AccountsService)(nil)
+
+// New creates a mock which reports unexpected calls and return values of the wrong type to t.
+// t can be nil, in which case return values of the wrong type panic.
+func New(t TestingT) *AccountsService {
+	return &AccountsService{t: t}
+}
+
+// On sets up an expectation of a call to method, such as "Fetch", with args. Arguments are
+// compared with reflect.DeepEqual, and Anything matches any argument. Expectations are matched
+// in the order they were set up.
+func (m *AccountsService) On(method string, args ...interface{}) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := &Expectation{method: method, args: args}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// Calls returns the calls made to the mock, in order.
+func (m *AccountsService) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo returns the calls made to method, in order.
+func (m *AccountsService) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// AssertExpectations reports to t any expectation limited with Times which was not called that many times,
+// and any expectation without a limit which was not called at all. It reports whether every expectation was met.
+func (m *AccountsService) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	met := true
+	for _, e := range m.expectations {
+		if (e.times > 0 && e.calls != e.times) || (e.times == 0 && e.calls == 0) {
+			t.Errorf("mock: expected %s(%v) to be called %s, but it was called %d times", e.method, formatArgs(e.args), formatTimes(e.times), e.calls)
+			met = false
+		}
+	}
+	return met
+}
+
+// Create records the call and returns the values of the matching expectation.
+func (m *AccountsService) Create(account *apiclient.AccountData, opts ...apiclient.CallOption) (*apiclient.AccountData, error) {
+	returns, err := m.called("Create", opts, account)
+	if err != nil {
+		return nil, err
+	}
+	return returned[*apiclient.AccountData](m, returns, 0), returned[error](m, returns, 1)
+}
+
+// Fetch records the call and returns the values of the matching expectation.
+func (m *AccountsService) Fetch(accountID string, opts ...apiclient.CallOption) (*apiclient.AccountData, error) {
+	returns, err := m.called("Fetch", opts, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return returned[*apiclient.AccountData](m, returns, 0), returned[error](m, returns, 1)
+}
+
+// List records the call and returns the values of the matching expectation.
+func (m *AccountsService) List(params *apiclient.ListParams, opts ...apiclient.CallOption) (*apiclient.AccountListData, error) {
+	returns, err := m.called("List", opts, params)
+	if err != nil {
+		return nil, err
+	}
+	return returned[*apiclient.AccountListData](m, returns, 0), returned[error](m, returns, 1)
+}
+
+// Update records the call and returns the values of the matching expectation.
+func (m *AccountsService) Update(account *apiclient.AccountData, opts ...apiclient.CallOption) (*apiclient.AccountData, error) {
+	returns, err := m.called("Update", opts, account)
+	if err != nil {
+		return nil, err
+	}
+	return returned[*apiclient.AccountData](m, returns, 0), returned[error](m, returns, 1)
+}
+
+// Delete records the call and returns the value of the matching expectation.
+func (m *AccountsService) Delete(accountID string, version int, opts ...apiclient.CallOption) error {
+	returns, err := m.called("Delete", opts, accountID, version)
+	if err != nil {
+		return err
+	}
+	return returned[error](m, returns, 0)
+}
+
+// called records a call and returns the values of the first expectation it matches.
+func (m *AccountsService) called(method string, opts []apiclient.CallOption, args ...interface{}) (*callReturns, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args, Options: opts})
+
+	for _, e := range m.expectations {
+		if e.method != method || (e.times > 0 && e.calls >= e.times) || !matches(e.args, args) {
+			continue
+		}
+		e.calls++
+		m.mu.Unlock()
+		return &callReturns{method: method, values: e.returns}, nil
+	}
+	m.mu.Unlock()
+
+	if m.t != nil {
+		m.t.Helper()
+		m.t.Errorf("mock: unexpected call %s(%v)", method, formatArgs(args))
+	}
+	return nil, ErrUnexpectedCall
+}
+
+// matches reports whether the arguments of a call match the arguments of an expectation.
+func matches(expected []interface{}, actual []interface{}) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i := range expected {
+		if expected[i] == Anything {
+			continue
+		}
+		if !reflect.DeepEqual(expected[i], actual[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// callReturns are the values set up with Return for a call to method.
+type callReturns struct {
+	method string
+	values []interface{}
+}
+
+// returned returns value i of the values returned by a call, or the zero value of T if it was not
+// given or is nil. A value of another type is reported to the mock's TestingT rather than dropped.
+func returned[T any](m *AccountsService, returns *callReturns, i int) T {
+	var zero T
+	if i >= len(returns.values) || returns.values[i] == nil {
+		return zero
+	}
+	value, ok := returns.values[i].(T)
+	if !ok {
+		message := fmt.Sprintf("mock: %s returns %T as value %d, want %v", returns.method, returns.values[i], i, reflect.TypeOf((*T)(nil)).Elem())
+		if m.t == nil {
+			panic(message)
+		}
+		m.t.Helper()
+		m.t.Errorf("%s", message)
+	}
+	return value
+}
+
+func formatArgs(args []interface{}) string {
+	formatted := ""
+	for i, arg := range args {
+		if i > 0 {
+			formatted += ", "
+		}
+		formatted += fmt.Sprintf("%#v", arg)
+	}
+	return formatted
+}
+
+func formatTimes(times int) string {
+	if times == 0 {
+		return "at least once"
+	}
+	return fmt.Sprintf("%d times", times)
+}
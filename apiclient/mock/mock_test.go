@@ -0,0 +1,98 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rosalita/my-apiclient/apiclient"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingT records the failures reported to it.
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+// closeAccount is an example of code which depends on an AccountsService.
+func closeAccount(service apiclient.AccountsService, accountID string) error {
+	account, err := service.Fetch(accountID)
+	if err != nil {
+		return err
+	}
+	return service.Delete(accountID, *account.Data.Version)
+}
+
+func TestAccountsService(t *testing.T) {
+	version := 3
+	account := &apiclient.AccountData{Data: apiclient.Account{ID: "A", Version: &version}}
+
+	service := New(t)
+	service.On("Fetch", "A").Return(account, nil).Once()
+	service.On("Delete", "A", 3).Return(nil).Once()
+
+	err := closeAccount(service, "A")
+	assert.Equal(t, nil, err)
+	assert.True(t, service.AssertExpectations(t))
+
+	assert.Equal(t, []Call{
+		{Method: "Fetch", Args: []interface{}{"A"}},
+		{Method: "Delete", Args: []interface{}{"A", 3}},
+	}, service.Calls())
+	assert.Equal(t, 1, len(service.CallsTo("Delete")))
+}
+
+func TestAccountsServiceErrors(t *testing.T) {
+	failure := errors.New("failure")
+
+	service := New(t)
+	service.On("Fetch", Anything).Return(nil, failure)
+
+	err := closeAccount(service, "B")
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 0, len(service.CallsTo("Delete")))
+}
+
+func TestAccountsServiceUnexpectedCalls(t *testing.T) {
+	recorder := &recordingT{}
+	service := New(recorder)
+	service.On("List", Anything).Return(&apiclient.AccountListData{}, nil).Once()
+	service.On("Create", Anything).Return(nil, nil).Times(2)
+
+	_, err := service.List(nil)
+	assert.Equal(t, nil, err)
+	_, err = service.List(nil)
+	assert.Equal(t, ErrUnexpectedCall, err)
+	_, err = service.Update(&apiclient.AccountData{})
+	assert.Equal(t, ErrUnexpectedCall, err)
+	assert.Equal(t, 2, len(recorder.errors))
+
+	assert.False(t, service.AssertExpectations(recorder))
+	assert.Equal(t, "mock: expected Create(mock.Anything) to be called 2 times, but it was called 0 times", recorder.errors[2])
+}
+
+func TestAccountsServiceWrongReturnTypes(t *testing.T) {
+	recorder := &recordingT{}
+	service := New(recorder)
+	service.On("Fetch", "A").Return(apiclient.AccountData{}, "failure")
+
+	account, err := service.Fetch("A")
+	assert.Nil(t, account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{
+		"mock: Fetch returns apiclient.AccountData as value 0, want *apiclient.AccountData",
+		"mock: Fetch returns string as value 1, want error",
+	}, recorder.errors)
+
+	service = New(nil)
+	service.On("Delete", "A", 3).Return(errors.New)
+	assert.PanicsWithValue(t, "mock: Delete returns func(string) error as value 0, want error", func() {
+		service.Delete("A", 3)
+	})
+}
@@ -1,21 +1,25 @@
 package apiclient
 
+import "encoding/json"
+
 // AccountData contains the data for an account
-type AccountData struct {
-	Data Account `json:"data"`
+type AccountData Document[Account]
+
+// MarshalJSON marshals the account data as a Document.
+func (a AccountData) MarshalJSON() ([]byte, error) {
+	return Document[Account](a).MarshalJSON()
 }
 
 // AccountListData contains the data for multiple accounts
-type AccountListData struct {
-	Data  []Account `json:"data"`
-	Links PageLinks
-}
+type AccountListData ListDocument[Account]
 
-//PageLinks contains the links to paginated data
+// PageLinks contains the links to paginated data
 type PageLinks struct {
-	First string `json:"first"`
-	Last  string `json:"last"`
-	Self  string `json:"self"`
+	First string `json:"first,omitempty"`
+	Last  string `json:"last,omitempty"`
+	Self  string `json:"self,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
 }
 
 // Account represents a registered bank account
@@ -23,24 +27,82 @@ type Account struct {
 	AccountType    string            `json:"type"`
 	ID             string            `json:"id"`
 	OrganisationID string            `json:"organisation_id"`
+	Version        *int              `json:"version,omitempty"`
 	Attributes     AccountAttributes `json:"attributes"`
+	// Relationships link the account to other resources, keyed by the name of the relationship.
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+	// Extra holds members of the account which are not known to this version of apiclient,
+	// so that they are sent back unchanged when the account is updated.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-// AccountAttributes are the attributes of an account
+// AccountAttributes are the attributes of an account.
+// The attributes which identify the account are left out of JSON when they are empty. The others
+// are Optional, so that Update can clear them with Null and leaves them unchanged when absent.
 type AccountAttributes struct {
-	Country                     string   `json:"country"`
-	BaseCurrency                string   `json:"base_currency"`
-	AccountNumber               string   `json:"account_number"`
-	BankID                      string   `json:"bank_id"`
-	BankIDCode                  string   `json:"bank_id_code"`
-	Bic                         string   `json:"bic"`
-	Iban                        string   `json:"iban"`
-	Title                       string   `json:"title"`
-	FirstName                   string   `json:"first_name"`
-	BankAccountName             string   `json:"bank_account_name"`
-	AlternativeBankAccountNames []string `json:"alternative_bank_account_names"`
-	AccountClassification       string   `json:"account_classification"`
-	JointAccount                bool     `json:"joint_account"`
-	AccountMatchingOptOut       bool     `json:"account_matching_opt_out"`
-	SecondaryIdentification     string   `json:"secondary_identification"`
+	Country                     CountryCode           `json:"country,omitempty"`
+	BaseCurrency                CurrencyCode          `json:"base_currency,omitempty"`
+	AccountNumber               string                `json:"account_number,omitempty"`
+	BankID                      string                `json:"bank_id,omitempty"`
+	BankIDCode                  BankIDCode            `json:"bank_id_code,omitempty"`
+	Bic                         string                `json:"bic,omitempty"`
+	Iban                        string                `json:"iban,omitempty"`
+	Title                       Optional[string]      `json:"title,omitzero"`
+	FirstName                   Optional[string]      `json:"first_name,omitzero"`
+	BankAccountName             Optional[string]      `json:"bank_account_name,omitzero"`
+	AlternativeBankAccountNames Optional[[]string]    `json:"alternative_bank_account_names,omitzero"`
+	AccountClassification       AccountClassification `json:"account_classification,omitempty"`
+	JointAccount                Optional[bool]        `json:"joint_account,omitzero"`
+	AccountMatchingOptOut       Optional[bool]        `json:"account_matching_opt_out,omitzero"`
+	SecondaryIdentification     Optional[string]      `json:"secondary_identification,omitzero"`
+	// Name is the name of the account holder in up to 4 lines. It replaces FirstName and
+	// BankAccountName in newer versions of the Accounts API.
+	Name                       Optional[[]string]          `json:"name,omitzero"`
+	AlternativeNames           Optional[[]string]          `json:"alternative_names,omitzero"`
+	Status                     Optional[string]            `json:"status,omitzero"`
+	Switched                   Optional[bool]              `json:"switched,omitzero"`
+	ProcessingService          Optional[string]            `json:"processing_service,omitzero"`
+	UserDefinedInformation     Optional[string]            `json:"user_defined_information,omitzero"`
+	ValidationType             Optional[string]            `json:"validation_type,omitzero"`
+	ReferenceMask              Optional[string]            `json:"reference_mask,omitzero"`
+	AcceptanceQualifier        Optional[string]            `json:"acceptance_qualifier,omitzero"`
+	PrivateIdentification      *PrivateIdentification      `json:"private_identification,omitempty"`
+	OrganisationIdentification *OrganisationIdentification `json:"organisation_identification,omitempty"`
+	// Extra holds attributes which are not known to this version of apiclient,
+	// so that they are sent back unchanged when the account is updated.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// The statuses of an account.
+const (
+	AccountStatusPending   = "pending"
+	AccountStatusConfirmed = "confirmed"
+	AccountStatusFailed    = "failed"
+	AccountStatusClosed    = "closed"
+)
+
+// PrivateIdentification identifies the individual holding a personal account
+type PrivateIdentification struct {
+	BirthDate      string      `json:"birth_date,omitempty"`
+	BirthCountry   CountryCode `json:"birth_country,omitempty"`
+	Identification string      `json:"identification,omitempty"`
+	Address        []string    `json:"address,omitempty"`
+	City           string      `json:"city,omitempty"`
+	Country        CountryCode `json:"country,omitempty"`
+}
+
+// OrganisationIdentification identifies the organisation holding a business account
+type OrganisationIdentification struct {
+	Identification string      `json:"identification,omitempty"`
+	Actors         []Actor     `json:"actors,omitempty"`
+	Address        []string    `json:"address,omitempty"`
+	City           string      `json:"city,omitempty"`
+	Country        CountryCode `json:"country,omitempty"`
+}
+
+// Actor is a person who can act for the organisation holding a business account
+type Actor struct {
+	Name      []string    `json:"name,omitempty"`
+	BirthDate string      `json:"birth_date,omitempty"`
+	Residency CountryCode `json:"residency,omitempty"`
 }
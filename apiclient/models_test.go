@@ -13,18 +13,18 @@ func TestAccountDataUnmarshalJSON(t *testing.T) {
 		`"type":"A",` +
 		`"id":"B",` +
 		`"organisation_id":"C",` +
-		`"Attributes":{"country":"D",` +
-		`"base_currency":"E",` +
+		`"Attributes":{"country":"DE",` +
+		`"base_currency":"EUR",` +
 		`"account_number":"F",` +
 		`"bank_id":"G",` +
-		`"bank_id_code":"H",` +
+		`"bank_id_code":"DEBLZ",` +
 		`"bic":"I",` +
 		`"iban":"J",` +
 		`"title":"K",` +
 		`"first_name":"L",` +
 		`"bank_account_name":"M",` +
 		`"alternative_bank_account_names":["N","O"],` +
-		`"account_classification":"P",` +
+		`"account_classification":"Business",` +
 		`"joint_account":true,` +
 		`"account_matching_opt_out":true,` +
 		`"secondary_identification":"Q"` +
@@ -36,21 +36,21 @@ func TestAccountDataUnmarshalJSON(t *testing.T) {
 			ID:             "B",
 			OrganisationID: "C",
 			Attributes: AccountAttributes{
-				Country:                     "D",
-				BaseCurrency:                "E",
+				Country:                     "DE",
+				BaseCurrency:                "EUR",
 				AccountNumber:               "F",
 				BankID:                      "G",
-				BankIDCode:                  "H",
+				BankIDCode:                  "DEBLZ",
 				Bic:                         "I",
 				Iban:                        "J",
-				Title:                       "K",
-				FirstName:                   "L",
-				BankAccountName:             "M",
-				AlternativeBankAccountNames: []string{"N", "O"},
-				AccountClassification:       "P",
-				JointAccount:                true,
-				AccountMatchingOptOut:       true,
-				SecondaryIdentification:     "Q",
+				Title:                       Some("K"),
+				FirstName:                   Some("L"),
+				BankAccountName:             Some("M"),
+				AlternativeBankAccountNames: Some([]string{"N", "O"}),
+				AccountClassification:       "Business",
+				JointAccount:                Some(true),
+				AccountMatchingOptOut:       Some(true),
+				SecondaryIdentification:     Some("Q"),
 			},
 		},
 	}
@@ -68,21 +68,21 @@ func TestAccountDataMarshalJson(t *testing.T) {
 			ID:             "B",
 			OrganisationID: "C",
 			Attributes: AccountAttributes{
-				Country:                     "D",
-				BaseCurrency:                "E",
+				Country:                     "DE",
+				BaseCurrency:                "EUR",
 				AccountNumber:               "F",
 				BankID:                      "G",
-				BankIDCode:                  "H",
+				BankIDCode:                  "DEBLZ",
 				Bic:                         "I",
 				Iban:                        "J",
-				Title:                       "K",
-				FirstName:                   "L",
-				BankAccountName:             "M",
-				AlternativeBankAccountNames: []string{"N", "O"},
-				AccountClassification:       "P",
-				JointAccount:                true,
-				AccountMatchingOptOut:       true,
-				SecondaryIdentification:     "Q",
+				Title:                       Some("K"),
+				FirstName:                   Some("L"),
+				BankAccountName:             Some("M"),
+				AlternativeBankAccountNames: Some([]string{"N", "O"}),
+				AccountClassification:       "Business",
+				JointAccount:                Some(true),
+				AccountMatchingOptOut:       Some(true),
+				SecondaryIdentification:     Some("Q"),
 			},
 		},
 	}
@@ -92,18 +92,18 @@ func TestAccountDataMarshalJson(t *testing.T) {
 		`"type":"A",` +
 		`"id":"B",` +
 		`"organisation_id":"C",` +
-		`"attributes":{"country":"D",` +
-		`"base_currency":"E",` +
+		`"attributes":{"country":"DE",` +
+		`"base_currency":"EUR",` +
 		`"account_number":"F",` +
 		`"bank_id":"G",` +
-		`"bank_id_code":"H",` +
+		`"bank_id_code":"DEBLZ",` +
 		`"bic":"I",` +
 		`"iban":"J",` +
 		`"title":"K",` +
 		`"first_name":"L",` +
 		`"bank_account_name":"M",` +
 		`"alternative_bank_account_names":["N","O"],` +
-		`"account_classification":"P",` +
+		`"account_classification":"Business",` +
 		`"joint_account":true,` +
 		`"account_matching_opt_out":true,` +
 		`"secondary_identification":"Q"` +
@@ -179,6 +179,7 @@ func TestAccountListDataUnmarshalJSON(t *testing.T) {
 					AccountType:    "accounts",
 					ID:             "bd27e265-9605-4b4b-a0e5-3003ea9cc4dc",
 					OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+					Version:        &zero,
 					Attributes: AccountAttributes{Country: "GB",
 						BaseCurrency:                "GBP",
 						AccountNumber:               "41426819",
@@ -186,17 +187,21 @@ func TestAccountListDataUnmarshalJSON(t *testing.T) {
 						BankIDCode:                  "GBDSC",
 						Bic:                         "NWBKGB22",
 						Iban:                        "GB11NWBK40030041426819",
-						Title:                       "Ms",
-						FirstName:                   "Samantha",
-						BankAccountName:             "Samantha Holder",
-						AlternativeBankAccountNames: []string{"Sam Holder"},
+						Title:                       Some("Ms"),
+						FirstName:                   Some("Samantha"),
+						BankAccountName:             Some("Samantha Holder"),
+						AlternativeBankAccountNames: Some([]string{"Sam Holder"}),
 						AccountClassification:       "Personal",
-						JointAccount:                false,
-						AccountMatchingOptOut:       false,
-						SecondaryIdentification:     "A1B2C3D4"}},
+						JointAccount:                Some(false),
+						AccountMatchingOptOut:       Some(false),
+						SecondaryIdentification:     Some("A1B2C3D4")},
+					Extra: map[string]json.RawMessage{
+						"created_on":  json.RawMessage(`"2020-01-15T21:41:09.508Z"`),
+						"modified_on": json.RawMessage(`"2020-01-15T21:41:09.508Z"`)}},
 				Account{AccountType: "accounts",
 					ID:             "cd27e265-9605-4b4b-a0e5-3003ea9cc4dc",
 					OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+					Version:        &zero,
 					Attributes: AccountAttributes{
 						Country:                     "GB",
 						BaseCurrency:                "GBP",
@@ -205,14 +210,17 @@ func TestAccountListDataUnmarshalJSON(t *testing.T) {
 						BankIDCode:                  "GBDSC",
 						Bic:                         "NWBKGB22",
 						Iban:                        "GB11NWBK40030041426819",
-						Title:                       "Ms",
-						FirstName:                   "Samantha",
-						BankAccountName:             "Samantha Holder",
-						AlternativeBankAccountNames: []string{"Sam Holder"},
+						Title:                       Some("Ms"),
+						FirstName:                   Some("Samantha"),
+						BankAccountName:             Some("Samantha Holder"),
+						AlternativeBankAccountNames: Some([]string{"Sam Holder"}),
 						AccountClassification:       "Personal",
-						JointAccount:                false,
-						AccountMatchingOptOut:       false,
-						SecondaryIdentification:     "A1B2C3D4"}}},
+						JointAccount:                Some(false),
+						AccountMatchingOptOut:       Some(false),
+						SecondaryIdentification:     Some("A1B2C3D4")},
+					Extra: map[string]json.RawMessage{
+						"created_on":  json.RawMessage(`"2020-01-16T20:01:25.633Z"`),
+						"modified_on": json.RawMessage(`"2020-01-16T20:01:25.633Z"`)}}},
 			Links: PageLinks{
 				First: "/v1/organisation/accounts?page%5Bnumber%5D=first\u0026page%5Bsize%5D=%02",
 				Last:  "/v1/organisation/accounts?page%5Bnumber%5D=last\u0026page%5Bsize%5D=%02",
@@ -240,14 +248,14 @@ func TestAccountListDataMarshalJSON(t *testing.T) {
 					BankIDCode:                  "GBDSC",
 					Bic:                         "NWBKGB22",
 					Iban:                        "GB11NWBK40030041426819",
-					Title:                       "Ms",
-					FirstName:                   "Samantha",
-					BankAccountName:             "Samantha Holder",
-					AlternativeBankAccountNames: []string{"Sam Holder"},
+					Title:                       Some("Ms"),
+					FirstName:                   Some("Samantha"),
+					BankAccountName:             Some("Samantha Holder"),
+					AlternativeBankAccountNames: Some([]string{"Sam Holder"}),
 					AccountClassification:       "Personal",
-					JointAccount:                false,
-					AccountMatchingOptOut:       false,
-					SecondaryIdentification:     "A1B2C3D4"},
+					JointAccount:                Some(false),
+					AccountMatchingOptOut:       Some(false),
+					SecondaryIdentification:     Some("A1B2C3D4")},
 			},
 			{
 				AccountType:    "accounts",
@@ -261,14 +269,14 @@ func TestAccountListDataMarshalJSON(t *testing.T) {
 					BankIDCode:                  "GBDSC",
 					Bic:                         "NWBKGB22",
 					Iban:                        "GB11NWBK40030041426819",
-					Title:                       "Ms",
-					FirstName:                   "Samantha",
-					BankAccountName:             "Samantha Holder",
-					AlternativeBankAccountNames: []string{"Sam Holder"},
+					Title:                       Some("Ms"),
+					FirstName:                   Some("Samantha"),
+					BankAccountName:             Some("Samantha Holder"),
+					AlternativeBankAccountNames: Some([]string{"Sam Holder"}),
 					AccountClassification:       "Personal",
-					JointAccount:                false,
-					AccountMatchingOptOut:       false,
-					SecondaryIdentification:     "A1B2C3D4"},
+					JointAccount:                Some(false),
+					AccountMatchingOptOut:       Some(false),
+					SecondaryIdentification:     Some("A1B2C3D4")},
 			},
 		},
 		Links: PageLinks{
@@ -317,7 +325,7 @@ func TestAccountListDataMarshalJSON(t *testing.T) {
 		`"joint_account":false,` +
 		`"account_matching_opt_out":false,` +
 		`"secondary_identification":"A1B2C3D4"}}],` +
-		`"Links":{` +
+		`"links":{` +
 		`"first":"/v1/organisation/accounts?page%5Bnumber%5D=first\u0026page%5Bsize%5D=%02",` +
 		`"last":"/v1/organisation/accounts?page%5Bnumber%5D=last\u0026page%5Bsize%5D=%02",` +
 		`"self":"/v1/organisation/accounts?page%5Bnumber%5D=%00\u0026page%5Bsize%5D=%02"` +
@@ -327,3 +335,85 @@ func TestAccountListDataMarshalJSON(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, expectedJSON, string(json))
 }
+
+func TestAccountAttributesRoundTrip(t *testing.T) {
+	rawJSON := `{` +
+		`"country":"GB",` +
+		`"base_currency":"GBP",` +
+		`"account_number":"41426819",` +
+		`"bank_id":"400300",` +
+		`"bank_id_code":"GBDSC",` +
+		`"bic":"NWBKGB22",` +
+		`"iban":"GB16NWBK40030041426819",` +
+		`"account_classification":"Business",` +
+		`"joint_account":false,` +
+		`"account_matching_opt_out":false,` +
+		`"secondary_identification":"A1B2C3D4",` +
+		`"name":["Samantha Holder","Flat 1"],` +
+		`"alternative_names":["Sam Holder"],` +
+		`"status":"confirmed",` +
+		`"switched":false,` +
+		`"processing_service":"ABC Bank",` +
+		`"user_defined_information":"Some information",` +
+		`"validation_type":"card",` +
+		`"reference_mask":"############",` +
+		`"acceptance_qualifier":"same_day",` +
+		`"private_identification":{"birth_date":"2017-07-23","birth_country":"GB","identification":"13YH458762","address":["10 Avenue des Champs"],"city":"London","country":"GB"},` +
+		`"organisation_identification":{"identification":"123654","actors":[{"name":["Jeff Page"],"birth_date":"1970-01-01","residency":"GB"}],"address":["10 Avenue des Champs"],"city":"London","country":"GB"}` +
+		`}`
+
+	expectedAttributes := AccountAttributes{
+		Country:                 "GB",
+		BaseCurrency:            "GBP",
+		AccountNumber:           "41426819",
+		BankID:                  "400300",
+		BankIDCode:              "GBDSC",
+		Bic:                     "NWBKGB22",
+		Iban:                    "GB16NWBK40030041426819",
+		AccountClassification:   "Business",
+		JointAccount:            Some(false),
+		AccountMatchingOptOut:   Some(false),
+		SecondaryIdentification: Some("A1B2C3D4"),
+		Name:                    Some([]string{"Samantha Holder", "Flat 1"}),
+		AlternativeNames:        Some([]string{"Sam Holder"}),
+		Status:                  Some(AccountStatusConfirmed),
+		Switched:                Some(false),
+		ProcessingService:       Some("ABC Bank"),
+		UserDefinedInformation:  Some("Some information"),
+		ValidationType:          Some("card"),
+		ReferenceMask:           Some("############"),
+		AcceptanceQualifier:     Some("same_day"),
+		PrivateIdentification: &PrivateIdentification{
+			BirthDate:      "2017-07-23",
+			BirthCountry:   "GB",
+			Identification: "13YH458762",
+			Address:        []string{"10 Avenue des Champs"},
+			City:           "London",
+			Country:        "GB",
+		},
+		OrganisationIdentification: &OrganisationIdentification{
+			Identification: "123654",
+			Actors:         []Actor{{Name: []string{"Jeff Page"}, BirthDate: "1970-01-01", Residency: "GB"}},
+			Address:        []string{"10 Avenue des Champs"},
+			City:           "London",
+			Country:        "GB",
+		},
+	}
+
+	var attributes AccountAttributes
+	err := json.Unmarshal([]byte(rawJSON), &attributes)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedAttributes, attributes)
+
+	marshalled, err := json.Marshal(&attributes)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rawJSON, string(marshalled))
+}
+
+func TestAccountAttributesOmitsAbsentFields(t *testing.T) {
+	marshalled, err := json.Marshal(&AccountAttributes{Country: "GB"})
+	assert.Equal(t, nil, err)
+	assert.NotContains(t, string(marshalled), "switched")
+	assert.NotContains(t, string(marshalled), "private_identification")
+	assert.NotContains(t, string(marshalled), `"name"`)
+}
@@ -0,0 +1,102 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// marshalOmitZero marshals v, a struct, with encoding/json and then removes the members of the fields
+// tagged omitzero which are zero. encoding/json only applies omitzero from Go 1.24, and older versions
+// would send an absent Optional as null, clearing the attribute, and links with no members as {}.
+func marshalOmitZero(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	zero := zeroFields(reflect.ValueOf(v))
+	if len(zero) == 0 {
+		return data, nil
+	}
+	return removeMembers(data, zero)
+}
+
+// zeroFields returns the JSON names of the fields of struct v, and of the structs embedded in it,
+// which are tagged omitzero and are zero. A field's IsZero method is used if it has one.
+func zeroFields(v reflect.Value) map[string]bool {
+	zero := make(map[string]bool)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embedded := range zeroFields(v.Field(i)) {
+				zero[embedded] = true
+			}
+			continue
+		}
+		if tag == "-" || !field.IsExported() || !hasOption(options, "omitzero") {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		value := v.Field(i)
+		if z, ok := value.Interface().(interface{ IsZero() bool }); ok && z.IsZero() || !ok && value.IsZero() {
+			zero[name] = true
+		}
+	}
+	return zero
+}
+
+// hasOption reports whether option is one of the comma separated options of a JSON struct tag.
+func hasOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// removeMembers removes the named members from the JSON object in data, keeping the others in order.
+func removeMembers(data []byte, names map[string]bool) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		name, _ := token.(string)
+		if names[name] {
+			continue
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
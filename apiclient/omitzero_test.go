@@ -0,0 +1,57 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type embeddedFields struct {
+	Embedded Optional[bool] `json:"embedded,omitzero"`
+}
+
+type omitZeroFields struct {
+	embeddedFields
+	Count    int            `json:"count,string"`
+	Absent   Optional[bool] `json:"absent,omitzero"`
+	Present  Optional[bool] `json:"present,omitzero"`
+	Links    PageLinks      `json:"links,omitzero"`
+	Untagged PageLinks      `json:"untagged"`
+}
+
+func TestZeroFields(t *testing.T) {
+	v := omitZeroFields{Present: Null[bool]()}
+	assert.Equal(t, map[string]bool{"embedded": true, "absent": true, "links": true}, zeroFields(reflect.ValueOf(v)))
+
+	v = omitZeroFields{embeddedFields: embeddedFields{Some(true)}, Absent: Some(false), Links: PageLinks{Self: "/self"}}
+	assert.Equal(t, map[string]bool{"present": true}, zeroFields(reflect.ValueOf(v)))
+}
+
+func TestRemoveMembers(t *testing.T) {
+	data := []byte(`{"a":1,"b":{"a":2},"c":[null],"d":null,"<e>":"x"}`)
+
+	removed, err := removeMembers(data, map[string]bool{"a": true, "d": true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"b":{"a":2},"c":[null],"\u003ce\u003e":"x"}`, string(removed))
+
+	removed, err = removeMembers(data, map[string]bool{"a": true, "b": true, "c": true, "d": true, "<e>": true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{}`, string(removed))
+}
+
+func TestMarshalOmitZero(t *testing.T) {
+	// Everything but leaving out zero members is done by encoding/json.
+	marshalled, err := marshalOmitZero(omitZeroFields{Count: 3, Present: Some(true)})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"count":"3","present":true,"untagged":{}}`, string(marshalled))
+
+	document, err := json.Marshal(&AccountData{Data: Account{ID: "A"}})
+	assert.Equal(t, nil, err)
+	assert.NotContains(t, string(document), "links")
+
+	relationship, err := json.Marshal(Relationship{Data: json.RawMessage(`null`)})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"data":null}`, string(relationship))
+}
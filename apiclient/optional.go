@@ -0,0 +1,72 @@
+package apiclient
+
+import (
+	"encoding/json"
+)
+
+// Optional is a value which can be absent, null or set, for attributes where sending the zero value
+// means something different to leaving the attribute out, such as false for joint_account, or which
+// Update must be able to clear. The zero Optional is absent, and AccountAttributes leaves it out of JSON.
+type Optional[T any] struct {
+	value   T
+	present bool
+	null    bool
+}
+
+// Some returns an Optional set to value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, present: true}
+}
+
+// Null returns an Optional which is present but null, such as to clear an attribute with Update.
+func Null[T any]() Optional[T] {
+	return Optional[T]{present: true, null: true}
+}
+
+// Get returns the value and whether it is set. It returns the zero value of T and false if the
+// Optional is absent or null.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.present && !o.null
+}
+
+// ValueOr returns the value if it is set and fallback if it is absent or null.
+func (o Optional[T]) ValueOr(fallback T) T {
+	if value, ok := o.Get(); ok {
+		return value
+	}
+	return fallback
+}
+
+// IsNull reports whether the Optional is present but null.
+func (o Optional[T]) IsNull() bool {
+	return o.null
+}
+
+// IsZero reports whether the Optional is absent.
+func (o Optional[T]) IsZero() bool {
+	return !o.present
+}
+
+// MarshalJSON marshals the value, or null if the Optional is null or absent.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.present || o.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON sets the Optional to the value in data, or to null. It is only called for members
+// which are present, so an Optional left untouched by unmarshalling stays absent.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Null[T]()
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
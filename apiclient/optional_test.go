@@ -0,0 +1,112 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionalUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		rawJSON  string
+		expected Optional[bool]
+	}{
+		{`{}`, Optional[bool]{}},
+		{`{"joint_account":null}`, Null[bool]()},
+		{`{"joint_account":false}`, Some(false)},
+		{`{"joint_account":true}`, Some(true)},
+	}
+
+	for _, test := range tests {
+		var attributes AccountAttributes
+		err := json.Unmarshal([]byte(test.rawJSON), &attributes)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, test.expected, attributes.JointAccount, test.rawJSON)
+	}
+}
+
+func TestOptionalMarshalJSON(t *testing.T) {
+	tests := []struct {
+		attributes AccountAttributes
+		expected   string
+	}{
+		{AccountAttributes{}, `{}`},
+		{AccountAttributes{JointAccount: Null[bool]()}, `{"joint_account":null}`},
+		{AccountAttributes{JointAccount: Some(false)}, `{"joint_account":false}`},
+		{AccountAttributes{JointAccount: Some(true), Switched: Some(false)}, `{"joint_account":true,"switched":false}`},
+		// Empty identifying attributes are left out rather than sent as "".
+		{AccountAttributes{Iban: "", Country: "GB"}, `{"country":"GB"}`},
+		// Other text attributes can be cleared, or sent as "".
+		{AccountAttributes{Title: Null[string](), FirstName: Some("")}, `{"title":null,"first_name":""}`},
+	}
+
+	for _, test := range tests {
+		marshalled, err := json.Marshal(test.attributes)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, test.expected, string(marshalled))
+	}
+}
+
+func TestOptionalGet(t *testing.T) {
+	value, ok := Some(false).Get()
+	assert.Equal(t, false, value)
+	assert.True(t, ok)
+
+	_, ok = Null[bool]().Get()
+	assert.False(t, ok)
+	assert.True(t, Null[bool]().IsNull())
+	assert.False(t, Null[bool]().IsZero())
+
+	_, ok = Optional[bool]{}.Get()
+	assert.False(t, ok)
+	assert.True(t, Optional[bool]{}.IsZero())
+
+	assert.Equal(t, true, Optional[bool]{}.ValueOr(true))
+	assert.Equal(t, false, Some(false).ValueOr(true))
+}
+
+func TestOptionalUnmarshalJSONError(t *testing.T) {
+	var attributes AccountAttributes
+	err := json.Unmarshal([]byte(`{"joint_account":"yes"}`), &attributes)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestOptionalNamesAndStatusJSON(t *testing.T) {
+	tests := []struct {
+		rawJSON    string
+		attributes AccountAttributes
+	}{
+		// Absent attributes are left out, so Update leaves them unchanged.
+		{`{}`, AccountAttributes{}},
+		// Null attributes are sent, so Update clears them.
+		{`{"alternative_bank_account_names":null,"name":null,"alternative_names":null,"status":null}`, AccountAttributes{
+			AlternativeBankAccountNames: Null[[]string](),
+			Name:                        Null[[]string](),
+			AlternativeNames:            Null[[]string](),
+			Status:                      Null[string](),
+		}},
+		// Empty attributes are sent as they are.
+		{`{"alternative_bank_account_names":[],"name":[],"alternative_names":[],"status":""}`, AccountAttributes{
+			AlternativeBankAccountNames: Some([]string{}),
+			Name:                        Some([]string{}),
+			AlternativeNames:            Some([]string{}),
+			Status:                      Some(""),
+		}},
+		{`{"name":["Samantha Holder"],"status":"pending"}`, AccountAttributes{
+			Name:   Some([]string{"Samantha Holder"}),
+			Status: Some(AccountStatusPending),
+		}},
+	}
+
+	for _, test := range tests {
+		marshalled, err := json.Marshal(test.attributes)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, test.rawJSON, string(marshalled))
+
+		var attributes AccountAttributes
+		err = json.Unmarshal([]byte(test.rawJSON), &attributes)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, test.attributes, attributes, test.rawJSON)
+	}
+}
@@ -0,0 +1,39 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CallOption configures a single call to the Accounts API.
+type CallOption func(*callOptions)
+
+// callOptions holds the settings built up from the CallOptions passed to a call.
+type callOptions struct {
+	ctx         context.Context
+	retryPolicy *RetryPolicy
+	hedgeDelay  *time.Duration
+	header      http.Header
+}
+
+// newCallOptions applies opts on top of the defaults for a call.
+func newCallOptions(opts []CallOption) *callOptions {
+	o := &callOptions{
+		ctx: context.Background(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithContext sets the context used for a call. Cancelling the context aborts
+// the call, including any time spent waiting on the rate limiter or between retries.
+func WithContext(ctx context.Context) CallOption {
+	return func(o *callOptions) {
+		if ctx != nil {
+			o.ctx = ctx
+		}
+	}
+}
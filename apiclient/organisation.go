@@ -0,0 +1,108 @@
+package apiclient
+
+import (
+	"errors"
+)
+
+// ErrOrganisationMismatch is returned by an OrganisationClient for an account which belongs to another organisation.
+var ErrOrganisationMismatch = errors.New("account belongs to another organisation")
+
+// OrganisationClient makes requests for the accounts of a single organisation, so that a service
+// acting for many organisations cannot reach the accounts of one while acting for another.
+type OrganisationClient struct {
+	client         *Client
+	organisationID string
+}
+
+// Organisation returns an OrganisationClient for the accounts of the organisation.
+func (c *Client) Organisation(organisationID string) *OrganisationClient {
+	return &OrganisationClient{client: c, organisationID: organisationID}
+}
+
+// ID returns the id of the organisation.
+func (o *OrganisationClient) ID() string {
+	return o.organisationID
+}
+
+// Create creates an account for the organisation, setting its organisation_id if it is empty.
+// It returns ErrOrganisationMismatch if the account has the id of another organisation.
+func (o *OrganisationClient) Create(account *AccountData, opts ...CallOption) (*AccountData, error) {
+	stamped, err := o.stamp(account)
+	if err != nil {
+		return nil, err
+	}
+	return Create(o.client, stamped, opts...)
+}
+
+// Fetch gets an account of the organisation.
+// It returns ErrOrganisationMismatch if the account belongs to another organisation.
+func (o *OrganisationClient) Fetch(accountID string, opts ...CallOption) (*AccountData, error) {
+	account, err := Fetch(o.client, accountID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if account.Data.OrganisationID != o.organisationID {
+		return nil, ErrOrganisationMismatch
+	}
+	return account, nil
+}
+
+// List lists the accounts of the organisation. Any OrganisationIDs in params are replaced.
+func (o *OrganisationClient) List(params *ListParams, opts ...CallOption) (*AccountListData, error) {
+	var filtered ListParams
+	if params != nil {
+		filtered = *params
+	}
+	filtered.OrganisationIDs = []string{o.organisationID}
+
+	accountList, err := List(o.client, &filtered, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The filter is applied by the Accounts API, but accounts of other organisations are
+	// dropped here as well in case it is ignored.
+	accounts := accountList.Data[:0]
+	for _, account := range accountList.Data {
+		if account.OrganisationID == o.organisationID {
+			accounts = append(accounts, account)
+		}
+	}
+	accountList.Data = accounts
+
+	return accountList, nil
+}
+
+// Update changes an account of the organisation, setting its organisation_id if it is empty.
+// It returns ErrOrganisationMismatch if the account has the id of another organisation.
+func (o *OrganisationClient) Update(account *AccountData, opts ...CallOption) (*AccountData, error) {
+	stamped, err := o.stamp(account)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := o.Fetch(account.Data.ID, opts...); err != nil {
+		return nil, err
+	}
+	return Update(o.client, stamped, opts...)
+}
+
+// Delete deletes an account of the organisation. The account is fetched first to check which
+// organisation it belongs to, and ErrOrganisationMismatch is returned if it belongs to another.
+func (o *OrganisationClient) Delete(accountID string, version int, opts ...CallOption) error {
+	if _, err := o.Fetch(accountID, opts...); err != nil {
+		return err
+	}
+	return Delete(o.client, accountID, version, opts...)
+}
+
+// stamp returns a copy of account with the organisation's id.
+func (o *OrganisationClient) stamp(account *AccountData) (*AccountData, error) {
+	switch account.Data.OrganisationID {
+	case "", o.organisationID:
+		stamped := *account
+		stamped.Data.OrganisationID = o.organisationID
+		return &stamped, nil
+	default:
+		return nil, ErrOrganisationMismatch
+	}
+}
@@ -0,0 +1,121 @@
+package apiclient
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ownOrganisationID   = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+	otherOrganisationID = "0d27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+)
+
+// organisationAccountsHandler serves account "own" of ownOrganisationID and account "other" of
+// otherOrganisationID, counting the requests which change accounts.
+func organisationAccountsHandler(changes *int32, filters *[]string) http.HandlerFunc {
+	accounts := map[string]string{
+		"own":   `{"type":"accounts","id":"own","organisation_id":"` + ownOrganisationID + `","version":0,"attributes":{"country":"GB"}}`,
+		"other": `{"type":"accounts","id":"other","organisation_id":"` + otherOrganisationID + `","version":0,"attributes":{"country":"GB"}}`,
+	}
+
+	return func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		id := strings.TrimPrefix(req.URL.Path, "/v1/organisation/accounts/")
+
+		switch {
+		case req.URL.Path == "/v1/organisation/accounts" && req.Method == "GET":
+			*filters = append(*filters, req.URL.Query().Get("filter[organisation_id]"))
+			// The filter is ignored, so both accounts are returned.
+			rw.WriteHeader(200)
+			rw.Write([]byte(`{"data":[` + accounts["own"] + `,` + accounts["other"] + `],"links":{}}`))
+
+		case req.Method == "POST" || req.Method == "PATCH":
+			atomic.AddInt32(changes, 1)
+			rw.WriteHeader(201)
+			rw.Write(body)
+
+		case req.Method == "DELETE":
+			atomic.AddInt32(changes, 1)
+			rw.WriteHeader(204)
+
+		case req.Method == "GET" && accounts[id] != "":
+			rw.WriteHeader(200)
+			rw.Write([]byte(`{"data":` + accounts[id] + `}`))
+
+		default:
+			rw.WriteHeader(404)
+		}
+	}
+}
+
+func TestOrganisationClient(t *testing.T) {
+	var changes int32
+	var filters []string
+	testServer := httptest.NewServer(organisationAccountsHandler(&changes, &filters))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	org := client.Organisation(ownOrganisationID)
+	assert.Equal(t, ownOrganisationID, org.ID())
+
+	account, err := org.Fetch("own")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "own", account.Data.ID)
+
+	_, err = org.Fetch("other")
+	assert.Equal(t, ErrOrganisationMismatch, err)
+
+	accountList, err := org.List(&ListParams{PageSize: &two, OrganisationIDs: []string{otherOrganisationID}})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(accountList.Data))
+	assert.Equal(t, "own", accountList.Data[0].ID)
+	assert.Equal(t, []string{ownOrganisationID}, filters)
+
+	err = org.Delete("other", 0)
+	assert.Equal(t, ErrOrganisationMismatch, err)
+	err = org.Delete("own", 0)
+	assert.Equal(t, nil, err)
+
+	other := &AccountData{Data: Account{ID: "other", OrganisationID: ownOrganisationID, Version: &zero}}
+	_, err = org.Update(other)
+	assert.Equal(t, ErrOrganisationMismatch, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&changes))
+}
+
+func TestOrganisationClientCreate(t *testing.T) {
+	var changes int32
+	var filters []string
+	testServer := httptest.NewServer(organisationAccountsHandler(&changes, &filters))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	org := client.Organisation(ownOrganisationID)
+
+	payload, err := NewGBAccount(ownOrganisationID).WithSortCode("400300").WithAccountNumber("41426819").WithBIC("NWBKGB22").Build()
+	assert.Equal(t, nil, err)
+	payload.Data.OrganisationID = ""
+
+	created, err := org.Create(payload)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ownOrganisationID, created.Data.OrganisationID)
+	// The caller's account is not changed.
+	assert.Equal(t, "", payload.Data.OrganisationID)
+
+	payload.Data.OrganisationID = otherOrganisationID
+	_, err = org.Create(payload)
+	assert.Equal(t, ErrOrganisationMismatch, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&changes))
+}
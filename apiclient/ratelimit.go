@@ -0,0 +1,116 @@
+package apiclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket used to limit the rate of requests made to the Accounts API.
+// A single RateLimiter is safe to share between goroutines and between clients.
+type RateLimiter struct {
+	// Adaptive halves the rate each time a 429 response is received, down to MinRate.
+	Adaptive bool
+	// MinRate is the lowest rate in requests per second an Adaptive limiter will fall to.
+	MinRate float64
+
+	mu     sync.Mutex
+	rate   float64
+	burst  int
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing rate requests per second with bursts of up to burst requests.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		MinRate: rate / 16,
+		rate:    rate,
+		burst:   burst,
+		tokens:  float64(burst),
+		last:    time.Now(),
+	}
+}
+
+// Rate returns the current rate of the limiter in requests per second.
+func (l *RateLimiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.rate
+}
+
+// SetRate changes the rate of the limiter, for example to restore it after it has adapted to 429 responses.
+func (l *RateLimiter) SetRate(rate float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+	l.rate = rate
+}
+
+// Wait blocks until a request may be made or ctx is done.
+// A limiter with a rate of zero or less does not limit requests.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	if l.rate <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	l.refill(time.Now())
+	l.tokens--
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Hand back the token reserved above so other waiters are not delayed by a cancelled call.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// throttled is called when the Accounts API responds with a 429 and slows an Adaptive limiter down.
+func (l *RateLimiter) throttled() {
+	if !l.Adaptive {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+	l.rate /= 2
+	if l.rate < l.MinRate {
+		l.rate = l.MinRate
+	}
+}
+
+// refill adds the tokens accumulated since the last refill. l.mu must be held.
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	if elapsed <= 0 || l.rate <= 0 {
+		return
+	}
+
+	l.tokens += elapsed * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+}
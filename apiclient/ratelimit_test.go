@@ -0,0 +1,103 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := NewRateLimiter(100, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		err := limiter.Wait(context.Background())
+		assert.Equal(t, nil, err)
+	}
+
+	// The burst of 2 is free, the remaining 2 requests wait 10ms each.
+	assert.True(t, time.Since(start) >= 15*time.Millisecond)
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	assert.Equal(t, nil, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestRateLimiterSharedAcrossGoroutines(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(fetchHandler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.RateLimiter = NewRateLimiter(200, 1)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := Fetch(client, "validAccountID")
+			assert.Equal(t, nil, err)
+		}()
+	}
+	wg.Wait()
+
+	// 5 requests at 200 per second with a burst of 1 need at least 4 intervals of 5ms.
+	assert.True(t, time.Since(start) >= 20*time.Millisecond)
+}
+
+func TestRateLimiterAdaptive(t *testing.T) {
+	calls := 0
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		if calls == 1 {
+			rw.WriteHeader(429)
+			return
+		}
+		rw.WriteHeader(204)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.RateLimiter = NewRateLimiter(1000, 10)
+	client.RateLimiter.Adaptive = true
+
+	err := Delete(client, "validAccountID", 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 500.0, client.RateLimiter.Rate())
+}
+
+func TestDoRequestContextCancelled(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(fetchHandler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	accountData, err := Fetch(client, "internalServerError", WithContext(ctx))
+	assert.Nil(t, accountData)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -0,0 +1,161 @@
+package apiclient
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// ErrorClass is a category of error returned when a request could not be made.
+type ErrorClass int
+
+// The classes of error recognised by ClassifyError.
+const (
+	ErrorClassUnknown ErrorClass = iota
+	ErrorClassTimeout
+	ErrorClassConnectionRefused
+	ErrorClassConnectionReset
+	ErrorClassDNS
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassTimeout:
+		return "timeout"
+	case ErrorClassConnectionRefused:
+		return "connection refused"
+	case ErrorClassConnectionReset:
+		return "connection reset"
+	case ErrorClassDNS:
+		return "dns"
+	default:
+		return "unknown"
+	}
+}
+
+// ClassifyError returns the class of an error returned by the HTTP client.
+// A connection closed by the server before the response was complete, as happens when
+// a keep-alive connection is reused just as the server times it out, is classed as a reset.
+// Only temporary DNS failures are classed as ErrorClassDNS; a host which does not exist is unknown.
+func ClassifyError(err error) ErrorClass {
+	// A body which cannot be decompressed was received in full, even if the decoder reports EOF.
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		return ErrorClassUnknown
+	}
+
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrorClassConnectionRefused
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return ErrorClassConnectionReset
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorClassConnectionReset
+	case err != nil && strings.Contains(err.Error(), "server closed idle connection"):
+		// net/http does not export the error it returns when a reused connection is closed.
+		return ErrorClassConnectionReset
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		if dnsErr.IsTimeout || dnsErr.IsTemporary {
+			return ErrorClassDNS
+		}
+		return ErrorClassUnknown
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorClassTimeout
+	}
+
+	return ErrorClassUnknown
+}
+
+// RetryPolicy decides which failed attempts are retried.
+// Attempts are retried while the client's RetryStrategy allows.
+type RetryPolicy struct {
+	// Statuses are the response status codes which are retried.
+	Statuses []int
+	// Errors are the classes of error which are retried.
+	Errors []ErrorClass
+}
+
+// DefaultIdempotentRetryPolicy returns the policy used for GET and DELETE requests.
+// These can safely be repeated so every transient failure is retried.
+func DefaultIdempotentRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		Statuses: []int{429, 500, 502, 503, 504},
+		Errors:   []ErrorClass{ErrorClassTimeout, ErrorClassConnectionRefused, ErrorClassConnectionReset, ErrorClassDNS},
+	}
+}
+
+// DefaultNonIdempotentRetryPolicy returns the policy used for POST and PATCH requests.
+// Only failures where the Accounts API cannot have acted on the request are retried,
+// so an account is never created twice.
+func DefaultNonIdempotentRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		Statuses: []int{429, 503},
+		Errors:   []ErrorClass{ErrorClassConnectionRefused, ErrorClassDNS},
+	}
+}
+
+// RetryStatus reports whether an attempt which received statusCode should be retried.
+func (p *RetryPolicy) RetryStatus(statusCode int) bool {
+	for _, status := range p.Statuses {
+		if status == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryError reports whether an attempt which failed with err should be retried.
+func (p *RetryPolicy) RetryError(err error) bool {
+	class := ClassifyError(err)
+	if class == ErrorClassUnknown {
+		return false
+	}
+
+	for _, c := range p.Errors {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// WithRetryPolicy overrides the client's retry policy for a call.
+func WithRetryPolicy(policy *RetryPolicy) CallOption {
+	return func(o *callOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// retryPolicy returns the policy used for requests made with method.
+func (c *Client) retryPolicy(method string) *RetryPolicy {
+	if isIdempotent(method) {
+		if c.IdempotentRetryPolicy != nil {
+			return c.IdempotentRetryPolicy
+		}
+		return DefaultIdempotentRetryPolicy()
+	}
+
+	if c.NonIdempotentRetryPolicy != nil {
+		return c.NonIdempotentRetryPolicy
+	}
+	return DefaultNonIdempotentRetryPolicy()
+}
+
+// isIdempotent reports whether a request made with method can safely be repeated.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,208 @@
+package apiclient
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err   error
+		class ErrorClass
+	}{
+		{&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, ErrorClassConnectionRefused},
+		{&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, ErrorClassConnectionReset},
+		{&net.OpError{Op: "write", Err: os.NewSyscallError("write", syscall.EPIPE)}, ErrorClassConnectionReset},
+		{&net.OpError{Op: "read", Err: timeoutError{}}, ErrorClassTimeout},
+		{io.EOF, ErrorClassConnectionReset},
+		{io.ErrUnexpectedEOF, ErrorClassConnectionReset},
+		{&net.DNSError{Err: "server misbehaving", IsTemporary: true}, ErrorClassDNS},
+		{&net.DNSError{Err: "no such host", IsNotFound: true}, ErrorClassUnknown},
+		{&DecodeError{Coding: "gzip", Err: io.ErrUnexpectedEOF}, ErrorClassUnknown},
+		{errors.New("something else"), ErrorClassUnknown},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.class, ClassifyError(test.err))
+	}
+}
+
+func TestRetryPolicyPerMethod(t *testing.T) {
+	var calls int32
+	var bodies []string
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		call := atomic.AddInt32(&calls, 1)
+		body, _ := ioutil.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+
+		switch {
+		case strings.HasPrefix(req.URL.Path, "/v1/organisation/accounts/badGateway"):
+			if call == 1 {
+				rw.WriteHeader(502)
+				return
+			}
+			rw.WriteHeader(204)
+		case req.Method == "POST" && call == 1:
+			rw.WriteHeader(503)
+		default:
+			rw.WriteHeader(500)
+		}
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	// DELETE is idempotent so a 502 is retried.
+	err := Delete(client, "badGateway", 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// POST retries the 503 with the same body but does not retry the 500.
+	client.DisableValidation = true
+	atomic.StoreInt32(&calls, 0)
+	bodies = nil
+	_, err = Create(client, &AccountData{Data: Account{ID: "A"}})
+	assert.Equal(t, errors.New("status code not ok"), err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, bodies[0], bodies[1])
+	assert.Contains(t, bodies[1], `"id":"A"`)
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	var calls int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(500)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	accountData, err := Fetch(client, "validAccountID", WithRetryPolicy(&RetryPolicy{}))
+	assert.Nil(t, accountData)
+	assert.Equal(t, errors.New("status code not ok"), err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryConnectionRefused(t *testing.T) {
+	// Reserve an address and close the listener so that connections to it are refused.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Equal(t, nil, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	limitTimeout := 50 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New("http://"+addr, limitTimeout, clientTimeout)
+
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Nil(t, accountData)
+	assert.Equal(t, errors.New("retry timeout error"), err)
+
+	_, err = Fetch(client, "validAccountID", WithRetryPolicy(&RetryPolicy{}))
+	assert.Equal(t, ErrorClassConnectionRefused, ClassifyError(err))
+}
+
+// droppingListener closes the first drops connections it accepts without sending a response.
+type droppingListener struct {
+	net.Listener
+	drops int32
+}
+
+func (l *droppingListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if atomic.AddInt32(&l.drops, -1) < 0 {
+			return conn, nil
+		}
+		conn.Close()
+	}
+}
+
+// newDroppingServer starts a test server whose listener drops the first drops connections.
+func newDroppingServer(handler http.HandlerFunc, drops int32) *httptest.Server {
+	testServer := httptest.NewUnstartedServer(handler)
+	testServer.Listener = &droppingListener{Listener: testServer.Listener, drops: drops}
+	testServer.Start()
+	return testServer
+}
+
+func TestRetryDroppedConnection(t *testing.T) {
+	testServer := newDroppingServer(fetchHandler, 2)
+	defer testServer.Close()
+
+	limitTimeout := time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", accountData.Data.ID)
+}
+
+func TestDroppedConnectionNotRetriedForPost(t *testing.T) {
+	testServer := newDroppingServer(createHandler, 1)
+	defer testServer.Close()
+
+	limitTimeout := time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.DisableValidation = true
+
+	accountData, err := Create(client, &AccountData{Data: Account{ID: "bd27e265-9605-4b4b-a0e5-3003ea9cc4dc"}})
+	assert.Nil(t, accountData)
+	assert.Equal(t, ErrorClassConnectionReset, ClassifyError(err))
+}
+
+func TestRetryTruncatedBody(t *testing.T) {
+	var calls int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// Promise a longer body than is sent, then drop the connection.
+			conn, buf, _ := rw.(http.Hijacker).Hijack()
+			buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 100\r\n\r\n{\"data\":")
+			buf.Flush()
+			conn.Close()
+			return
+		}
+		fetchHandler(rw, req)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", accountData.Data.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
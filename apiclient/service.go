@@ -0,0 +1,16 @@
+package apiclient
+
+// AccountsService is the account operations of the Accounts API. Code which depends on an
+// AccountsService rather than a *Client can be tested with the mock in the mock subpackage.
+type AccountsService interface {
+	Create(account *AccountData, opts ...CallOption) (*AccountData, error)
+	Fetch(accountID string, opts ...CallOption) (*AccountData, error)
+	List(params *ListParams, opts ...CallOption) (*AccountListData, error)
+	Update(account *AccountData, opts ...CallOption) (*AccountData, error)
+	Delete(accountID string, version int, opts ...CallOption) error
+}
+
+var (
+	_ AccountsService = (*Client)(nil)
+	_ AccountsService = (*OrganisationClient)(nil)
+)
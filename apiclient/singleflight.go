@@ -0,0 +1,49 @@
+package apiclient
+
+import (
+	"sync"
+)
+
+// flightGroup coalesces concurrent calls with the same key into a single call whose
+// result is shared by every caller.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a call in progress or completed in a flightGroup.
+type flight struct {
+	wg   sync.WaitGroup
+	body []byte
+	err  error
+}
+
+// do calls fn unless a call for key is already in flight, in which case it waits for that
+// call and returns its result. shared reports whether the result came from another caller's call.
+func (g *flightGroup) do(key string, fn func() ([]byte, error)) (body []byte, err error, shared bool) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	if f, ok := g.flights[key]; ok {
+		g.mu.Unlock()
+		f.wg.Wait()
+		return f.body, f.err, true
+	}
+
+	f := &flight{}
+	f.wg.Add(1)
+	g.flights[key] = f
+	g.mu.Unlock()
+
+	// The flight is always completed, even if fn panics, so waiting callers are not stranded.
+	defer func() {
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		f.wg.Done()
+	}()
+
+	f.body, f.err = fn()
+	return f.body, f.err, false
+}
@@ -0,0 +1,102 @@
+package apiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicateFetches(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		fetchHandler(rw, req)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.DeduplicateFetches = true
+
+	const n = 10
+	results := make([]*AccountData, n)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = Fetch(client, "validAccountID")
+		}(i)
+	}
+
+	// Hold the response until every goroutine has joined the request in flight.
+	assert.Eventually(t, func() bool { return client.Stats().Requests == 1 && atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for i := 0; i < n; i++ {
+		assert.Equal(t, nil, errs[i])
+		assert.Equal(t, results[0], results[i])
+	}
+
+	// Each caller gets its own copy of the account.
+	results[0].Data.ID = "changed"
+	assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", results[1].Data.ID)
+}
+
+func TestDeduplicateFetchesSharesErrors(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		fetchHandler(rw, req)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.DeduplicateFetches = true
+
+	const n = 5
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = Fetch(client, "notFoundAccount")
+		}(i)
+	}
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for i := 0; i < n; i++ {
+		assert.Equal(t, "status code not ok", errs[i].Error())
+	}
+
+	// Once the call has completed a new one is made.
+	_, err := Fetch(client, "notFoundAccount")
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,36 @@
+package apiclient
+
+import (
+	"sync/atomic"
+)
+
+// Stats are counts of the work done by a Client since it was created.
+type Stats struct {
+	// Requests is the number of requests made to the Accounts API by any of the client's
+	// methods, each counted once however many attempts it took.
+	Requests int64
+	// Attempts is the number of HTTP requests sent, including retries and hedges.
+	Attempts int64
+	// Retries is the number of attempts which were retried.
+	Retries int64
+	// Hedges is the number of hedged attempts sent while an earlier attempt was still in flight.
+	Hedges int64
+}
+
+// clientStats holds the counters behind Stats. They are updated atomically.
+type clientStats struct {
+	requests int64
+	attempts int64
+	retries  int64
+	hedges   int64
+}
+
+// Stats returns a snapshot of the client's counters.
+func (c *Client) Stats() Stats {
+	return Stats{
+		Requests: atomic.LoadInt64(&c.stats.requests),
+		Attempts: atomic.LoadInt64(&c.stats.attempts),
+		Retries:  atomic.LoadInt64(&c.stats.retries),
+		Hedges:   atomic.LoadInt64(&c.stats.hedges),
+	}
+}
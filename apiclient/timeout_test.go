@@ -0,0 +1,106 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hangingHandler hangs on the first hangs requests until the client gives up, then serves fetchHandler.
+func hangingHandler(calls *int32, hangs int32) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(calls, 1) <= hangs {
+			// The request context is only cancelled on disconnect once the body has been read.
+			ioutil.ReadAll(req.Body)
+			select {
+			case <-req.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		fetchHandler(rw, req)
+	}
+}
+
+func TestAttemptTimeout(t *testing.T) {
+	var calls int32
+	testServer := httptest.NewServer(hangingHandler(&calls, 1))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.AttemptTimeout = 50 * time.Millisecond
+
+	start := time.Now()
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", accountData.Data.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestAttemptTimeoutNotRetriedForPost(t *testing.T) {
+	var calls int32
+	testServer := httptest.NewServer(hangingHandler(&calls, 1))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.AttemptTimeout = 50 * time.Millisecond
+	client.DisableValidation = true
+
+	accountData, err := Create(client, &AccountData{})
+	assert.Nil(t, accountData)
+	assert.Equal(t, ErrorClassTimeout, ClassifyError(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestOperationTimeout(t *testing.T) {
+	var calls int32
+	testServer := httptest.NewServer(hangingHandler(&calls, 1000))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.AttemptTimeout = 30 * time.Millisecond
+	client.OperationTimeout = 200 * time.Millisecond
+
+	start := time.Now()
+	accountData, err := Fetch(client, "validAccountID")
+	assert.Nil(t, accountData)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, atomic.LoadInt32(&calls) > 1)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestOperationTimeoutWithContext(t *testing.T) {
+	var calls int32
+	testServer := httptest.NewServer(hangingHandler(&calls, 1000))
+	defer testServer.Close()
+
+	limitTimeout := 10 * time.Second
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+	client.AttemptTimeout = time.Second
+	client.OperationTimeout = 10 * time.Second
+
+	// The earlier of the caller's deadline and the operation timeout applies.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := Fetch(client, "validAccountID", WithContext(ctx))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+}
@@ -0,0 +1,24 @@
+package apiclient
+
+import (
+	"fmt"
+)
+
+// Update is the same as client.Update.
+func Update(client *Client, account *AccountData, opts ...CallOption) (*AccountData, error) {
+	return client.Update(account, opts...)
+}
+
+// Update changes the attributes of an existing account.
+// The account's Version must match the current version held by the Accounts API.
+func (c *Client) Update(account *AccountData, opts ...CallOption) (*AccountData, error) {
+	path := fmt.Sprintf("%s/%s", accountsPath, account.Data.ID)
+	defer c.invalidate(account.Data.ID)
+
+	updatedAccount, err := UpdateResource(c, path, (*Document[Account])(account), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*AccountData)(updatedAccount), nil
+}
@@ -0,0 +1,95 @@
+package apiclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func updateHandler(rw http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	bodyStr := string(body)
+
+	switch {
+	case req.URL.String() == "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc" && req.Method == "PATCH" && strings.Contains(bodyStr, `"version":0`):
+		responseJSON := `{` +
+			`"data":{` +
+			`"type":"accounts",` +
+			`"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",` +
+			`"organisation_id":"eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",` +
+			`"version":1,` +
+			`"attributes":{"country":"GB",` +
+			`"bank_account_name":"Samantha Smith"` +
+			`}}}`
+
+		rw.WriteHeader(200)
+		rw.Write([]byte(responseJSON))
+
+	case req.URL.String() == "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc" && req.Method == "PATCH":
+		responseJSON := `{"error_message":"invalid version"}`
+
+		rw.WriteHeader(409)
+		rw.Write([]byte(responseJSON))
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	validPayload := &AccountData{
+		Data: Account{
+			AccountType:    "accounts",
+			ID:             "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+			Version:        &zero,
+			Attributes: AccountAttributes{
+				Country:         "GB",
+				BankAccountName: Some("Samantha Smith"),
+			},
+		},
+	}
+
+	expectedAccount := AccountData(
+		AccountData{
+			Data: Account{
+				AccountType:    "accounts",
+				ID:             "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+				OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+				Version:        &one,
+				Attributes: AccountAttributes{
+					Country:         "GB",
+					BankAccountName: Some("Samantha Smith"),
+				},
+			},
+		},
+	)
+
+	stalePayload := *validPayload
+	stalePayload.Data.Version = &two
+
+	tests := []struct {
+		payload     *AccountData
+		accountData *AccountData
+		err         error
+	}{
+		{validPayload, &expectedAccount, nil},
+		{&stalePayload, nil, errors.New("status code not ok")},
+	}
+
+	// Create a new client and configure it to use test server instead of the real API endpoint.
+	testServer := httptest.NewServer(http.HandlerFunc(updateHandler))
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	client := New(testServer.URL, limitTimeout, clientTimeout)
+
+	for _, test := range tests {
+		accountData, err := Update(client, test.payload)
+		assert.Equal(t, test.accountData, accountData)
+		assert.Equal(t, test.err, err)
+	}
+}
@@ -0,0 +1,286 @@
+package apiclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rosalita/my-apiclient/apiclient/validation"
+)
+
+// FieldError describes a field of an account which breaks a validation rule.
+type FieldError struct {
+	// Field is the path of the field in the JSON representation of the account, such as "attributes.bank_id".
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// ValidationErrors are all of the validation rules broken by an account.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return "validation failure list:\n" + strings.Join(messages, "\n")
+}
+
+// countryRule holds the rules the Accounts API applies to the attributes of accounts in a country.
+type countryRule struct {
+	// bankIDLengths are the allowed lengths of the bank id. A nil slice means a bank id is not supported.
+	bankIDLengths  []int
+	bankIDRequired bool
+	bankIDNumeric  bool
+	// bankIDCode is the bank id code required alongside a bank id.
+	bankIDCode  BankIDCode
+	bicRequired bool
+	// accountNumberMin and accountNumberMax bound the length of the account number.
+	accountNumberMin     int
+	accountNumberMax     int
+	accountNumberNumeric bool
+	ibanForbidden        bool
+	// check applies any rules which do not fit the fields above.
+	check func(attributes *AccountAttributes, errs *ValidationErrors)
+}
+
+// countryRules are the rules of each country supported by the Accounts API.
+var countryRules = map[CountryCode]countryRule{
+	CountryGB: {bankIDLengths: []int{6}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodeGBDSC, bicRequired: true, accountNumberMin: 8, accountNumberMax: 8, accountNumberNumeric: true},
+	CountryAU: {bankIDLengths: []int{6}, bankIDNumeric: true, bankIDCode: BankIDCodeAUBSB, bicRequired: true, accountNumberMin: 6, accountNumberMax: 10, ibanForbidden: true, check: checkAUAccountNumber},
+	CountryBE: {bankIDLengths: []int{3}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodeBE, accountNumberMin: 7, accountNumberMax: 7, accountNumberNumeric: true},
+	CountryCA: {bankIDLengths: []int{9}, bankIDNumeric: true, bankIDCode: BankIDCodeCACPA, bicRequired: true, accountNumberMin: 7, accountNumberMax: 12, ibanForbidden: true, check: checkCABankID},
+	CountryFR: {bankIDLengths: []int{10}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodeFR, accountNumberMin: 10, accountNumberMax: 10},
+	CountryDE: {bankIDLengths: []int{8}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodeDEBLZ, accountNumberMin: 7, accountNumberMax: 7, accountNumberNumeric: true},
+	CountryGR: {bankIDLengths: []int{7}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodeGRBIC, accountNumberMin: 16, accountNumberMax: 16, accountNumberNumeric: true},
+	CountryHK: {bankIDLengths: []int{3}, bankIDNumeric: true, bankIDCode: BankIDCodeHKNCC, bicRequired: true, accountNumberMin: 9, accountNumberMax: 12, accountNumberNumeric: true, ibanForbidden: true},
+	CountryIT: {bankIDLengths: []int{10, 11}, bankIDRequired: true, bankIDCode: BankIDCodeITNCC, accountNumberMin: 12, accountNumberMax: 12, check: checkITBankID},
+	CountryLU: {bankIDLengths: []int{3}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodeLU, accountNumberMin: 13, accountNumberMax: 13},
+	CountryNL: {bicRequired: true, accountNumberMin: 10, accountNumberMax: 10, accountNumberNumeric: true},
+	CountryPL: {bankIDLengths: []int{8}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodePLKNR, accountNumberMin: 16, accountNumberMax: 16, accountNumberNumeric: true},
+	CountryPT: {bankIDLengths: []int{8}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodePTNCC, accountNumberMin: 11, accountNumberMax: 11, accountNumberNumeric: true},
+	CountryES: {bankIDLengths: []int{8}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodeESNCC, accountNumberMin: 10, accountNumberMax: 10, accountNumberNumeric: true},
+	CountryCH: {bankIDLengths: []int{5}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodeCHBCC, accountNumberMin: 12, accountNumberMax: 12},
+	CountryUS: {bankIDLengths: []int{9}, bankIDRequired: true, bankIDNumeric: true, bankIDCode: BankIDCodeUSABA, bicRequired: true, accountNumberMin: 6, accountNumberMax: 17, ibanForbidden: true},
+}
+
+// Validator checks accounts against the rules applied by the Accounts API.
+// The zero Validator applies every rule except the checking of UK account numbers.
+type Validator struct {
+	// ModulusWeights is the VocaLink modulus weight table used to check the account numbers of
+	// UK accounts against their sort codes. UK account numbers are not checked while it is nil.
+	ModulusWeights *validation.WeightTable
+	// LenientEnums skips checking that the country, base currency, account classification and
+	// bank id code are known to this version of apiclient. The country is still required.
+	LenientEnums bool
+}
+
+// Validate checks the account with the zero Validator.
+func (a *AccountData) Validate() error {
+	return Validator{}.Validate(a)
+}
+
+// Validate checks the account against the rules applied by the Accounts API, including the rules
+// for the account's country, and returns every rule broken as ValidationErrors.
+// It returns nil if the account is valid.
+func (v Validator) Validate(a *AccountData) error {
+	var errs ValidationErrors
+	account := &a.Data
+	attributes := &account.Attributes
+
+	if account.AccountType != "accounts" {
+		errs.add("type", "must be accounts")
+	}
+	if account.ID == "" {
+		errs.add("id", "is required")
+	}
+	if account.OrganisationID == "" {
+		errs.add("organisation_id", "is required")
+	}
+
+	switch {
+	case attributes.Country == "":
+		errs.add("attributes.country", "is required")
+	case !v.LenientEnums && !attributes.Country.Valid():
+		errs.add("attributes.country", "must be an ISO 3166-1 alpha-2 country code")
+	}
+	if !v.LenientEnums {
+		if attributes.BaseCurrency != "" && !attributes.BaseCurrency.Valid() {
+			errs.add("attributes.base_currency", "must be an ISO 4217 currency code")
+		}
+		if attributes.AccountClassification != "" && !attributes.AccountClassification.Valid() {
+			errs.add("attributes.account_classification", "must be one of [Personal Business]")
+		}
+		if attributes.BankIDCode != "" && !attributes.BankIDCode.Valid() {
+			errs.add("attributes.bank_id_code", "is not a known bank id code")
+		}
+	}
+	lines, _ := attributes.Name.Get()
+	if len(lines) > 4 {
+		errs.add("attributes.name", "must have at most 4 lines")
+	}
+	for _, line := range lines {
+		if line == "" || len(line) > 140 {
+			errs.add("attributes.name", "lines must be 1 to 140 characters long")
+			break
+		}
+	}
+	if attributes.Bic != "" {
+		if err := validation.ValidateBIC(attributes.Bic); err != nil {
+			errs.add("attributes.bic", err.Error())
+		}
+	}
+	if attributes.Iban != "" {
+		iban := validation.NormaliseIBAN(attributes.Iban)
+		if err := validation.ValidateIBAN(iban); err != nil {
+			errs.add("attributes.iban", err.Error())
+		} else if attributes.Country.Valid() && iban[:2] != string(attributes.Country) {
+			errs.add("attributes.iban", fmt.Sprintf("must be an iban for country %s", attributes.Country))
+		}
+	}
+
+	if rule, ok := countryRules[attributes.Country]; ok {
+		rule.validate(attributes, &errs)
+	}
+	if attributes.Country == CountryGB {
+		v.checkGBModulus(attributes, &errs)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validate checks attributes against the rule.
+func (r countryRule) validate(attributes *AccountAttributes, errs *ValidationErrors) {
+	country := attributes.Country
+
+	switch {
+	case r.bankIDLengths == nil:
+		if attributes.BankID != "" {
+			errs.add("attributes.bank_id", fmt.Sprintf("is not supported for country %s", country))
+		}
+	case attributes.BankID == "":
+		if r.bankIDRequired {
+			errs.add("attributes.bank_id", fmt.Sprintf("is required for country %s", country))
+		}
+	default:
+		if !containsInt(r.bankIDLengths, len(attributes.BankID)) {
+			errs.add("attributes.bank_id", fmt.Sprintf("must be %s characters long for country %s", joinInts(r.bankIDLengths), country))
+		}
+		if r.bankIDNumeric && !isDigits(attributes.BankID) {
+			errs.add("attributes.bank_id", fmt.Sprintf("must only contain digits for country %s", country))
+		}
+	}
+
+	switch {
+	case r.bankIDCode == "":
+		if attributes.BankIDCode != "" {
+			errs.add("attributes.bank_id_code", fmt.Sprintf("is not supported for country %s", country))
+		}
+	case attributes.BankIDCode != r.bankIDCode && (attributes.BankID != "" || attributes.BankIDCode != ""):
+		errs.add("attributes.bank_id_code", fmt.Sprintf("must be %s for country %s", r.bankIDCode, country))
+	}
+
+	if r.bicRequired && attributes.Bic == "" {
+		errs.add("attributes.bic", fmt.Sprintf("is required for country %s", country))
+	}
+
+	if attributes.AccountNumber != "" {
+		length := len(attributes.AccountNumber)
+		if length < r.accountNumberMin || length > r.accountNumberMax {
+			errs.add("attributes.account_number", fmt.Sprintf("must be %s characters long for country %s", lengthRange(r.accountNumberMin, r.accountNumberMax), country))
+		}
+		if r.accountNumberNumeric && !isDigits(attributes.AccountNumber) {
+			errs.add("attributes.account_number", fmt.Sprintf("must only contain digits for country %s", country))
+		}
+	}
+
+	if r.ibanForbidden && attributes.Iban != "" {
+		errs.add("attributes.iban", fmt.Sprintf("is not supported for country %s", country))
+	}
+
+	if r.check != nil {
+		r.check(attributes, errs)
+	}
+}
+
+// checkGBModulus checks UK account numbers against their sort codes using the ModulusWeights.
+func (v Validator) checkGBModulus(attributes *AccountAttributes, errs *ValidationErrors) {
+	if v.ModulusWeights == nil || len(attributes.BankID) != 6 || len(attributes.AccountNumber) != 8 {
+		return
+	}
+	if err := v.ModulusWeights.Check(attributes.BankID, attributes.AccountNumber); err == validation.ErrModulusCheck {
+		errs.add("attributes.account_number", "is not valid for the sort code in bank_id")
+	}
+}
+
+// checkAUAccountNumber applies the Australian rule that account numbers cannot start with 0.
+func checkAUAccountNumber(attributes *AccountAttributes, errs *ValidationErrors) {
+	if strings.HasPrefix(attributes.AccountNumber, "0") {
+		errs.add("attributes.account_number", "must not start with 0 for country AU")
+	}
+}
+
+// checkCABankID applies the Canadian rule that bank ids start with 0.
+func checkCABankID(attributes *AccountAttributes, errs *ValidationErrors) {
+	if attributes.BankID != "" && !strings.HasPrefix(attributes.BankID, "0") {
+		errs.add("attributes.bank_id", "must start with 0 for country CA")
+	}
+}
+
+// checkITBankID applies the Italian rule that the bank id is 10 characters long without an
+// account number and 11 characters long, including the check character, with one.
+func checkITBankID(attributes *AccountAttributes, errs *ValidationErrors) {
+	if attributes.BankID == "" {
+		return
+	}
+	if attributes.AccountNumber == "" && len(attributes.BankID) == 11 {
+		errs.add("attributes.bank_id", "must be 10 characters long without an account number for country IT")
+	}
+	if attributes.AccountNumber != "" && len(attributes.BankID) == 10 {
+		errs.add("attributes.bank_id", "must be 11 characters long with an account number for country IT")
+	}
+}
+
+// add appends a FieldError for field.
+func (e *ValidationErrors) add(field string, message string) {
+	*e = append(*e, FieldError{Field: field, Message: message})
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, " or ")
+}
+
+func lengthRange(min, max int) string {
+	if min == max {
+		return fmt.Sprint(min)
+	}
+	return fmt.Sprintf("%d to %d", min, max)
+}
@@ -0,0 +1,144 @@
+package apiclient
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rosalita/my-apiclient/apiclient/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+// accountIn returns a minimal valid account with the given attributes.
+func accountIn(attributes AccountAttributes) *AccountData {
+	return &AccountData{
+		Data: Account{
+			AccountType:    "accounts",
+			ID:             "bd27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+			Attributes:     attributes,
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		account *AccountData
+		err     error
+	}{
+		{accountIn(AccountAttributes{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", Bic: "NWBKGB22", AccountNumber: "41426819"}), nil},
+		{accountIn(AccountAttributes{Country: "GB", BankID: "40030", BankIDCode: "DEBLZ", AccountNumber: "4142681X"}), ValidationErrors{
+			{Field: "attributes.bank_id", Message: "must be 6 characters long for country GB"},
+			{Field: "attributes.bank_id_code", Message: "must be GBDSC for country GB"},
+			{Field: "attributes.bic", Message: "is required for country GB"},
+			{Field: "attributes.account_number", Message: "must only contain digits for country GB"},
+		}},
+		{accountIn(AccountAttributes{Country: "DE", BankID: "37040044", BankIDCode: "DEBLZ", AccountNumber: "0532013"}), nil},
+		{accountIn(AccountAttributes{Country: "DE", BankID: "3704004A", BankIDCode: "DEBLZ"}), ValidationErrors{
+			{Field: "attributes.bank_id", Message: "must only contain digits for country DE"},
+		}},
+		{accountIn(AccountAttributes{Country: "DE"}), ValidationErrors{
+			{Field: "attributes.bank_id", Message: "is required for country DE"},
+		}},
+		{accountIn(AccountAttributes{Country: "AU", BankIDCode: "AUBSB", Bic: "NATAAU33", AccountNumber: "0123456", Iban: "AU0000"}), ValidationErrors{
+			{Field: "attributes.iban", Message: "iban country code does not use IBANs"},
+			{Field: "attributes.iban", Message: "is not supported for country AU"},
+			{Field: "attributes.account_number", Message: "must not start with 0 for country AU"},
+		}},
+		{accountIn(AccountAttributes{Country: "NL", BankID: "1234", BankIDCode: "GBDSC", Bic: "ABNANL2A"}), ValidationErrors{
+			{Field: "attributes.bank_id", Message: "is not supported for country NL"},
+			{Field: "attributes.bank_id_code", Message: "is not supported for country NL"},
+		}},
+		{accountIn(AccountAttributes{Country: "IT", BankID: "X0542811101", BankIDCode: "ITNCC"}), ValidationErrors{
+			{Field: "attributes.bank_id", Message: "must be 10 characters long without an account number for country IT"},
+		}},
+		{accountIn(AccountAttributes{Country: "US", BankID: "021000021", BankIDCode: "USABA", Bic: "CHASUS33", AccountNumber: "12345"}), ValidationErrors{
+			{Field: "attributes.account_number", Message: "must be 6 to 17 characters long for country US"},
+		}},
+		{accountIn(AccountAttributes{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", Bic: "NWBKGB22", AccountNumber: "41426819", Iban: "GB16 NWBK 4003 0041 4268 19"}), nil},
+		{accountIn(AccountAttributes{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", Bic: "NWBK-GB22", AccountNumber: "41426819", Iban: "GB11NWBK40030041426819"}), ValidationErrors{
+			{Field: "attributes.bic", Message: validation.ErrBICFormat.Error()},
+			{Field: "attributes.iban", Message: validation.ErrIBANChecksum.Error()},
+		}},
+		{accountIn(AccountAttributes{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", Bic: "NWBKGB22", AccountNumber: "41426819", Iban: "DE89370400440532013000"}), ValidationErrors{
+			{Field: "attributes.iban", Message: "must be an iban for country GB"},
+		}},
+		{accountIn(AccountAttributes{Country: "JP", Name: Some([]string{"A", "B", "C", "D", ""})}), ValidationErrors{
+			{Field: "attributes.name", Message: "must have at most 4 lines"},
+			{Field: "attributes.name", Message: "lines must be 1 to 140 characters long"},
+		}},
+		// Countries without rules of their own only have the general rules applied.
+		{accountIn(AccountAttributes{Country: "JP", BankID: "anything"}), nil},
+		{&AccountData{Data: Account{AccountType: "account", Attributes: AccountAttributes{Country: "ZZ", BaseCurrency: "ZZZ"}}}, ValidationErrors{
+			{Field: "type", Message: "must be accounts"},
+			{Field: "id", Message: "is required"},
+			{Field: "organisation_id", Message: "is required"},
+			{Field: "attributes.country", Message: "must be an ISO 3166-1 alpha-2 country code"},
+			{Field: "attributes.base_currency", Message: "must be an ISO 4217 currency code"},
+		}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.err, test.account.Validate())
+	}
+}
+
+func TestValidateModulus(t *testing.T) {
+	weights, err := validation.LoadWeightTable(strings.NewReader("400000 409999 MOD11 0 0 0 0 0 0 8 7 6 5 4 3 2 1\n"))
+	assert.Equal(t, nil, err)
+
+	validator := Validator{ModulusWeights: weights}
+
+	valid := accountIn(AccountAttributes{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", Bic: "NWBKGB22", AccountNumber: "12345679"})
+	assert.Equal(t, nil, validator.Validate(valid))
+
+	invalid := accountIn(AccountAttributes{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", Bic: "NWBKGB22", AccountNumber: "12345678"})
+	assert.Equal(t, ValidationErrors{
+		{Field: "attributes.account_number", Message: "is not valid for the sort code in bank_id"},
+	}, validator.Validate(invalid))
+
+	// Account numbers are not checked without a weight table.
+	assert.Equal(t, nil, invalid.Validate())
+}
+
+func TestCreateValidatesWithClientValidator(t *testing.T) {
+	var posts int32
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&posts, 1)
+		body, _ := ioutil.ReadAll(req.Body)
+		rw.WriteHeader(201)
+		rw.Write(body)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer testServer.Close()
+
+	weights, err := validation.LoadWeightTable(strings.NewReader("400000 409999 MOD11 0 0 0 0 0 0 8 7 6 5 4 3 2 1\n"))
+	assert.Equal(t, nil, err)
+
+	limitTimeout := 10 * time.Millisecond
+	clientTimeout := 10 * time.Second
+	checking := New(testServer.URL, limitTimeout, clientTimeout)
+	checking.Validator.ModulusWeights = weights
+	other := New(testServer.URL, limitTimeout, clientTimeout)
+
+	account := accountIn(AccountAttributes{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", Bic: "NWBKGB22", AccountNumber: "12345678"})
+	_, err = Create(checking, account)
+	assert.IsType(t, ValidationErrors{}, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&posts))
+
+	_, err = Create(other, account)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&posts))
+}
+
+func TestValidationErrorsError(t *testing.T) {
+	err := ValidationErrors{
+		{Field: "id", Message: "is required"},
+		{Field: "attributes.country", Message: "is required"},
+	}
+	assert.Equal(t, "validation failure list:\nid is required\nattributes.country is required", err.Error())
+}
@@ -0,0 +1,21 @@
+package validation
+
+import (
+	"errors"
+)
+
+// ErrBICFormat is returned by ValidateBIC when a BIC is not correctly formed.
+var ErrBICFormat = errors.New("bic must be 8 or 11 characters: a 4 letter bank code, 2 letter country code, 2 character location code and optional 3 character branch code")
+
+// ValidateBIC checks that a BIC (SWIFT code) is correctly formed. It is 8 or 11 characters long:
+// a 4 letter bank code, a 2 letter country code, a 2 character location code and an optional
+// 3 character branch code.
+func ValidateBIC(bic string) error {
+	if len(bic) != 8 && len(bic) != 11 {
+		return ErrBICFormat
+	}
+	if !isLetters(bic[:6]) || !isAlphanumeric(bic[6:]) {
+		return ErrBICFormat
+	}
+	return nil
+}
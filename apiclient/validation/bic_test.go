@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBIC(t *testing.T) {
+	tests := []struct {
+		bic string
+		err error
+	}{
+		{"NWBKGB22", nil},
+		{"DEUTDEFF500", nil},
+		{"NWBKGB2", ErrBICFormat},
+		{"NWBKGB22XX", ErrBICFormat},
+		{"NWB1GB22", ErrBICFormat},
+		{"nwbkgb22", ErrBICFormat},
+		{"NWBKGB2-", ErrBICFormat},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.err, ValidateBIC(test.bic), test.bic)
+	}
+}
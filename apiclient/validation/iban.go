@@ -0,0 +1,136 @@
+// Package validation checks the identifiers used in bank account details: IBANs, BICs and
+// UK sort code and account number combinations.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Errors returned by ValidateIBAN.
+var (
+	ErrIBANFormat   = errors.New("iban must be a country code and check digits followed by letters and digits")
+	ErrIBANCountry  = errors.New("iban country code does not use IBANs")
+	ErrIBANLength   = errors.New("iban is not the correct length for its country")
+	ErrIBANChecksum = errors.New("iban check digits are not correct")
+)
+
+// ibanLengths are the lengths of the IBANs used by each country in the SWIFT IBAN registry.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HN": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26,
+	"IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21,
+	"LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28,
+	"NL": 18, "NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22,
+	"RU": 33, "SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25,
+	"SV": 28, "TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// NormaliseIBAN removes the spaces used when an IBAN is printed and upper-cases it.
+func NormaliseIBAN(iban string) string {
+	return strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+}
+
+// ValidateIBAN checks the format, length and mod-97 check digits of an IBAN.
+// The IBAN may be in its printed form with spaces.
+func ValidateIBAN(iban string) error {
+	iban = NormaliseIBAN(iban)
+
+	if len(iban) < 5 || !isLetters(iban[:2]) || !isDigits(iban[2:4]) || !isAlphanumeric(iban[4:]) {
+		return ErrIBANFormat
+	}
+
+	length, ok := ibanLengths[iban[:2]]
+	if !ok {
+		return ErrIBANCountry
+	}
+	if len(iban) != length {
+		return ErrIBANLength
+	}
+
+	if mod97(iban[4:]+iban[:4]) != 1 {
+		return ErrIBANChecksum
+	}
+	return nil
+}
+
+// IBAN builds the IBAN for a country's basic bank account number (BBAN), calculating its check digits.
+func IBAN(country string, bban string) (string, error) {
+	country = strings.ToUpper(country)
+	bban = strings.ToUpper(bban)
+
+	if len(country) != 2 || !isLetters(country) || bban == "" || !isAlphanumeric(bban) {
+		return "", ErrIBANFormat
+	}
+
+	checkDigits := 98 - mod97(bban+country+"00")
+	iban := fmt.Sprintf("%s%02d%s", country, checkDigits, bban)
+
+	if err := ValidateIBAN(iban); err != nil {
+		return "", err
+	}
+	return iban, nil
+}
+
+// GBIBAN builds a UK IBAN from the bank's BIC, the sort code (the account's bank_id) and the account number.
+// UK IBANs are made up of the first four letters of the BIC, the sort code and the account number.
+func GBIBAN(bic string, sortCode string, accountNumber string) (string, error) {
+	if err := ValidateBIC(bic); err != nil {
+		return "", err
+	}
+	if len(sortCode) != 6 || !isDigits(sortCode) {
+		return "", fmt.Errorf("sort code must be 6 digits")
+	}
+	if len(accountNumber) != 8 || !isDigits(accountNumber) {
+		return "", fmt.Errorf("account number must be 8 digits")
+	}
+
+	return IBAN("GB", strings.ToUpper(bic[:4])+sortCode+accountNumber)
+}
+
+// mod97 returns the remainder of the number formed by replacing each letter in s with
+// two digits (A = 10 ... Z = 35) when divided by 97.
+func mod97(s string) int {
+	var digits strings.Builder
+	for _, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(fmt.Sprint(int(r-'A') + 10))
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	return int(new(big.Int).Mod(n, big.NewInt(97)).Int64())
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
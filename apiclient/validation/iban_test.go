@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIBAN(t *testing.T) {
+	tests := []struct {
+		iban string
+		err  error
+	}{
+		{"GB16NWBK40030041426819", nil},
+		{"gb16 nwbk 4003 0041 4268 19", nil},
+		{"DE89370400440532013000", nil},
+		{"NL91ABNA0417164300", nil},
+		{"GB11NWBK40030041426819", ErrIBANChecksum},
+		{"GB16NWBK4003004142681", ErrIBANLength},
+		{"US16NWBK40030041426819", ErrIBANCountry},
+		{"GBXXNWBK40030041426819", ErrIBANFormat},
+		{"GB16NWBK-4003004142681", ErrIBANFormat},
+		{"GB1", ErrIBANFormat},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.err, ValidateIBAN(test.iban), test.iban)
+	}
+}
+
+func TestIBAN(t *testing.T) {
+	iban, err := IBAN("DE", "370400440532013000")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "DE89370400440532013000", iban)
+
+	_, err = IBAN("DE", "3704004405320130")
+	assert.Equal(t, ErrIBANLength, err)
+
+	_, err = IBAN("D1", "370400440532013000")
+	assert.Equal(t, ErrIBANFormat, err)
+}
+
+func TestGBIBAN(t *testing.T) {
+	iban, err := GBIBAN("NWBKGB22", "400300", "41426819")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "GB16NWBK40030041426819", iban)
+
+	_, err = GBIBAN("NWBK", "400300", "41426819")
+	assert.Equal(t, ErrBICFormat, err)
+
+	_, err = GBIBAN("NWBKGB22", "40030", "41426819")
+	assert.EqualError(t, err, "sort code must be 6 digits")
+
+	_, err = GBIBAN("NWBKGB22", "400300", "4142681X")
+	assert.EqualError(t, err, "account number must be 8 digits")
+}
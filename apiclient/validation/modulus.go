@@ -0,0 +1,315 @@
+package validation
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Errors returned by WeightTable.Check.
+var (
+	ErrSortCodeFormat      = errors.New("sort code must be 6 digits")
+	ErrAccountNumberFormat = errors.New("account number must be 8 digits")
+	ErrModulusCheck        = errors.New("account number is not valid for the sort code")
+)
+
+// The modulus check methods used in the weight table.
+const (
+	MethodMOD10 = "MOD10"
+	MethodMOD11 = "MOD11"
+	MethodDBLAL = "DBLAL"
+)
+
+// Positions of the digits of the combined sort code and account number, named as in the
+// VocaLink specification: u to z are the sort code and a to h the account number.
+const (
+	posU = iota
+	posV
+	posW
+	posX
+	posY
+	posZ
+	posA
+	posB
+	posC
+	posD
+	posE
+	posF
+	posG
+	posH
+)
+
+// WeightRow is a row of the weight table, giving the check applied to a range of sort codes.
+type WeightRow struct {
+	Start     int
+	End       int
+	Method    string
+	Weights   [14]int
+	Exception int
+}
+
+// WeightTable holds the VocaLink modulus weight table (valacdos.txt) and sort code
+// substitution table (scsubtab.txt) used to check UK sort code and account number combinations.
+// The tables are published by VocaLink and updated regularly, so are loaded rather than built in.
+type WeightTable struct {
+	Rows []WeightRow
+	// Substitutions maps sort codes to the sort code used in their place by exception 5.
+	Substitutions map[string]string
+}
+
+// LoadWeightTable reads a weight table in the format of valacdos.txt: one row per line of a start
+// sort code, end sort code, method, 14 weights and an optional exception, separated by spaces.
+func LoadWeightTable(r io.Reader) (*WeightTable, error) {
+	table := &WeightTable{Substitutions: map[string]string{}}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 17 && len(fields) != 18 {
+			return nil, fmt.Errorf("weight table line %d: expected 17 or 18 fields, got %d", line, len(fields))
+		}
+
+		var row WeightRow
+		numbers := make([]int, 0, 17)
+		for i, field := range fields {
+			if i == 2 {
+				continue
+			}
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("weight table line %d: %v", line, err)
+			}
+			numbers = append(numbers, n)
+		}
+
+		row.Start, row.End = numbers[0], numbers[1]
+		row.Method = fields[2]
+		copy(row.Weights[:], numbers[2:16])
+		if len(numbers) == 17 {
+			row.Exception = numbers[16]
+		}
+
+		if row.Method != MethodMOD10 && row.Method != MethodMOD11 && row.Method != MethodDBLAL {
+			return nil, fmt.Errorf("weight table line %d: unknown method %q", line, row.Method)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// LoadSubstitutions reads a sort code substitution table in the format of scsubtab.txt:
+// one line per sort code of the sort code and its substitute, separated by spaces.
+func (t *WeightTable) LoadSubstitutions(r io.Reader) error {
+	if t.Substitutions == nil {
+		t.Substitutions = map[string]string{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 || !isSortCode(fields[0]) || !isSortCode(fields[1]) {
+			return fmt.Errorf("substitution table line %d: expected two sort codes", line)
+		}
+		t.Substitutions[fields[0]] = fields[1]
+	}
+	return scanner.Err()
+}
+
+// Check runs the modulus checks for a sort code and account number, returning ErrModulusCheck
+// if the account number is not valid for the sort code. Sort codes not covered by the weight
+// table cannot be checked and are treated as valid, as the VocaLink specification requires.
+func (t *WeightTable) Check(sortCode string, accountNumber string) error {
+	if !isSortCode(sortCode) {
+		return ErrSortCodeFormat
+	}
+	if len(accountNumber) != 8 || !isDigits(accountNumber) {
+		return ErrAccountNumberFormat
+	}
+
+	rows := t.rows(sortCode)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	digits := toDigits(sortCode + accountNumber)
+
+	// Exception 6 accounts are foreign currency accounts which cannot be checked.
+	if rows[0].Exception == 6 && digits[posA] >= 4 && digits[posA] <= 8 && digits[posG] == digits[posH] {
+		return nil
+	}
+
+	first := t.pass(rows[0], digits)
+	if len(rows) == 1 {
+		return result(first)
+	}
+
+	second := rows[1]
+	switch {
+	case rows[0].Exception == 2 && second.Exception == 9,
+		rows[0].Exception == 10 && second.Exception == 11,
+		rows[0].Exception == 12 && second.Exception == 13:
+		// Either check passing is enough.
+		return result(first || t.pass(second, digits))
+	case second.Exception == 3 && (digits[posC] == 6 || digits[posC] == 9):
+		// The second check is skipped.
+		return result(first)
+	default:
+		return result(first && t.pass(second, digits))
+	}
+}
+
+// rows returns the rows of the weight table covering sortCode.
+func (t *WeightTable) rows(sortCode string) []WeightRow {
+	code, _ := strconv.Atoi(sortCode)
+
+	var rows []WeightRow
+	for _, row := range t.Rows {
+		if code >= row.Start && code <= row.End {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// pass reports whether digits pass the check of row, applying the row's exception.
+func (t *WeightTable) pass(row WeightRow, digits [14]int) bool {
+	weights := row.Weights
+
+	switch row.Exception {
+	case 2:
+		if digits[posA] != 0 {
+			if digits[posG] == 9 {
+				weights = [14]int{0, 0, 0, 0, 0, 0, 0, 0, 8, 7, 10, 9, 3, 1}
+			} else {
+				weights = [14]int{0, 0, 1, 2, 5, 3, 6, 4, 8, 7, 10, 9, 3, 1}
+			}
+		}
+	case 5:
+		if substitute, ok := t.Substitutions[sortCodeOf(digits)]; ok {
+			digits = withSortCode(digits, substitute)
+		}
+	case 7:
+		if digits[posG] == 9 {
+			zeroiseSortCode(&weights)
+		}
+	case 8:
+		digits = withSortCode(digits, "090126")
+	case 9:
+		digits = withSortCode(digits, "309634")
+	case 10:
+		ab := digits[posA]*10 + digits[posB]
+		if (ab == 9 || ab == 99) && digits[posG] == 9 {
+			zeroiseSortCode(&weights)
+		}
+	}
+
+	total := 0
+	for i, digit := range digits {
+		product := digit * weights[i]
+		if row.Method == MethodDBLAL {
+			total += product/10 + product%10
+		} else {
+			total += product
+		}
+	}
+
+	switch row.Method {
+	case MethodMOD10:
+		return total%10 == 0
+	case MethodDBLAL:
+		if row.Exception == 1 {
+			total += 27
+		}
+		if row.Exception == 5 {
+			remainder := total % 10
+			if remainder == 0 {
+				return digits[posH] == 0
+			}
+			return 10-remainder == digits[posH]
+		}
+		return total%10 == 0
+	default:
+		remainder := total % 11
+		switch row.Exception {
+		case 4:
+			return remainder == digits[posG]*10+digits[posH]
+		case 5:
+			switch remainder {
+			case 0:
+				return digits[posG] == 0
+			case 1:
+				return false
+			default:
+				return 11-remainder == digits[posG]
+			}
+		case 14:
+			if remainder == 0 {
+				return true
+			}
+			// Accounts ending in 0, 1 or 9 are checked again with that digit removed.
+			h := digits[posH]
+			if h != 0 && h != 1 && h != 9 {
+				return false
+			}
+			shifted := digits
+			copy(shifted[posB:], digits[posA:posH])
+			shifted[posA] = 0
+			return t.pass(WeightRow{Method: MethodMOD11, Weights: row.Weights}, shifted)
+		}
+		return remainder == 0
+	}
+}
+
+func result(pass bool) error {
+	if pass {
+		return nil
+	}
+	return ErrModulusCheck
+}
+
+func isSortCode(s string) bool {
+	return len(s) == 6 && isDigits(s)
+}
+
+func toDigits(s string) [14]int {
+	var digits [14]int
+	for i := range digits {
+		digits[i] = int(s[i] - '0')
+	}
+	return digits
+}
+
+func sortCodeOf(digits [14]int) string {
+	var b strings.Builder
+	for _, digit := range digits[:posA] {
+		b.WriteByte(byte('0' + digit))
+	}
+	return b.String()
+}
+
+func withSortCode(digits [14]int, sortCode string) [14]int {
+	for i := posU; i < posA; i++ {
+		digits[i] = int(sortCode[i] - '0')
+	}
+	return digits
+}
+
+// zeroiseSortCode sets the weights of u to b to zero, as exceptions 7 and 10 require.
+func zeroiseSortCode(weights *[14]int) {
+	for i := posU; i <= posB; i++ {
+		weights[i] = 0
+	}
+}
@@ -0,0 +1,159 @@
+package validation
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testWeights is a weight table for the exceptions that testdata/valacdos.txt has no rows for.
+// The rows are made up for the tests rather than taken from valacdos.txt.
+const testWeights = `
+100000 109999 MOD10    0    0    0    0    0    0    1    1    1    1    1    1    1    1
+200000 209999 DBLAL    0    0    0    0    0    0    2    1    2    1    2    1    2    1
+300000 309999 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1
+300000 309999 MOD10    0    0    0    0    0    0    1    1    1    1    1    1    1    1    3
+400000 409999 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1   14
+500000 509999 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1    6
+600000 609999 DBLAL    0    0    0    0    0    0    2    1    2    1    2    1    2    1    1
+700000 709999 MOD11    7    6    5    4    3    2    7    6    5    4    3    2    1    0    8
+800000 809999 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1   12
+800000 809999 MOD10    0    0    0    0    0    0    7    1    3    7    1    3    7    1   13
+310000 319999 MOD11    0    0    1    2    5    3    6    4    8    7   10    9    3    1    2
+310000 319999 MOD11    7    6    5    4    3    2    7    6    5    4    3    2    1    0    9
+`
+
+func TestWeightTableCheck(t *testing.T) {
+	table, err := LoadWeightTable(strings.NewReader(testWeights))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 12, len(table.Rows))
+
+	tests := []struct {
+		sortCode      string
+		accountNumber string
+		err           error
+	}{
+		{"100000", "12345672", nil},
+		{"109999", "12345671", ErrModulusCheck},
+		{"200000", "49927312", nil},
+		{"200000", "49927313", ErrModulusCheck},
+		// Both checks must pass.
+		{"300000", "12300004", nil},
+		{"300000", "12300012", ErrModulusCheck},
+		// Exception 3 skips the second check when c is 6 or 9.
+		{"300000", "12600008", nil},
+		// Exception 14 checks again without the last digit if it is 0, 1 or 9.
+		{"400000", "12300180", nil},
+		{"400000", "12300182", ErrModulusCheck},
+		// Exception 6 foreign currency accounts are not checked.
+		{"500000", "40000011", nil},
+		{"500000", "40000012", ErrModulusCheck},
+		// Exception 1 adds 27 to the double alternate total.
+		{"600000", "15826780", nil},
+		{"600000", "93393106", ErrModulusCheck},
+		// Exception 8 checks with sort code 090126.
+		{"700000", "13720696", nil},
+		// Exceptions 12 and 13 pass if either check passes.
+		{"800000", "42604684", nil},
+		{"800000", "04105718", ErrModulusCheck},
+		// Exception 9 checks again with sort code 309634 if exception 2 fails.
+		{"310000", "02996023", nil},
+		{"310000", "01235465", ErrModulusCheck},
+		// Sort codes outside the table cannot be checked.
+		{"900000", "12345678", nil},
+		{"10000", "12345672", ErrSortCodeFormat},
+		{"100000", "1234567", ErrAccountNumberFormat},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.err, table.Check(test.sortCode, test.accountNumber), test.sortCode+" "+test.accountNumber)
+	}
+}
+
+// loadTestdata loads the rows of valacdos.txt and scsubtab.txt needed for the VocaLink test cases.
+func loadTestdata(t *testing.T) *WeightTable {
+	f, err := os.Open("testdata/valacdos.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	table, err := LoadWeightTable(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err = os.Open("testdata/scsubtab.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := table.LoadSubstitutions(f); err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+// TestVocaLinkCases checks the test cases published with the VocaLink modulus checking specification.
+func TestVocaLinkCases(t *testing.T) {
+	table := loadTestdata(t)
+
+	tests := []struct {
+		sortCode      string
+		accountNumber string
+		err           error
+	}{
+		// Standard checks.
+		{"089999", "66374958", nil},
+		{"107999", "88837491", nil},
+		{"202959", "63748472", nil},
+		{"089999", "66374959", ErrModulusCheck},
+		{"107999", "88837493", ErrModulusCheck},
+		// Exception 4.
+		{"134020", "63849203", nil},
+		// Exception 5, with and without a substitution.
+		{"938611", "07806039", nil},
+		{"938600", "42368003", nil},
+		{"938063", "55065200", nil},
+		{"938063", "15764273", ErrModulusCheck},
+		{"938063", "15764264", ErrModulusCheck},
+		{"938063", "15763217", ErrModulusCheck},
+		// Exception 6.
+		{"200915", "41011166", nil},
+		// Exception 7.
+		{"772798", "99345694", nil},
+		// Exception 2.
+		{"309070", "12345677", nil},
+		{"309070", "99345694", nil},
+		// Exceptions 10 and 11.
+		{"871427", "46238510", nil},
+		{"872427", "46238510", nil},
+		{"871427", "09123496", nil},
+		{"871427", "99123496", nil},
+		// Exception 14.
+		{"180002", "00000190", nil},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.err, table.Check(test.sortCode, test.accountNumber), test.sortCode+" "+test.accountNumber)
+	}
+}
+
+func TestLoadWeightTableErrors(t *testing.T) {
+	_, err := LoadWeightTable(strings.NewReader("100000 109999 MOD10 0 0 0"))
+	assert.EqualError(t, err, "weight table line 1: expected 17 or 18 fields, got 6")
+
+	_, err = LoadWeightTable(strings.NewReader("100000 109999 MOD12 0 0 0 0 0 0 1 1 1 1 1 1 1 1"))
+	assert.EqualError(t, err, `weight table line 1: unknown method "MOD12"`)
+}
+
+func TestLoadSubstitutions(t *testing.T) {
+	table := &WeightTable{}
+	err := table.LoadSubstitutions(strings.NewReader("938173 938017\n938289 938068\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]string{"938173": "938017", "938289": "938068"}, table.Substitutions)
+
+	err = table.LoadSubstitutions(strings.NewReader("938173"))
+	assert.EqualError(t, err, "substitution table line 1: expected two sort codes")
+}